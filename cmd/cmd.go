@@ -19,6 +19,13 @@ func init() {
 	getCmd.AddCommand(getModulesCmd, getResourcesCmd)
 
 	rootCmd.AddCommand(resolveMtaCmd)
+	rootCmd.AddCommand(deployOrderCmd)
+	rootCmd.AddCommand(graphCmd)
+	rootCmd.AddCommand(impactCmd)
+	rootCmd.AddCommand(expandCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(codeActionsCmd)
 	updateCmd.AddCommand(updateModuleCmd, updateResourceCmd, updateBuildParametersCmd)
 
 }
@@ -11,6 +11,7 @@ var addModuleCmdData string
 var addModuleCmdForce bool
 var addModuleCmdHashcode int
 var getModulesCmdPath string
+var getModulesCmdReveal bool
 var updateModuleMtaCmdPath string
 var updateModuleCmdData string
 var updateModuleCmdHashcode int
@@ -27,6 +28,8 @@ func init() {
 		"data hashcode")
 	getModulesCmd.Flags().StringVarP(&getModulesCmdPath, "path", "p", "",
 		"the path to the yaml file")
+	getModulesCmd.Flags().BoolVarP(&getModulesCmdReveal, "reveal", "r", false,
+		"print the values of the sensitive properties and parameters instead of redacting them")
 	updateModuleCmd.Flags().StringVarP(&updateModuleMtaCmdPath, "path", "p", "",
 		"the path to the yaml file")
 	updateModuleCmd.Flags().StringVarP(&updateModuleCmdData, "data", "d", "",
@@ -59,7 +62,11 @@ var getModulesCmd = &cobra.Command{
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return mta.RunAndWriteResultAndHash("get modules", getModulesCmdPath, func() (interface{}, error) {
-			return mta.GetModules(getModulesCmdPath)
+			modules, err := mta.GetModules(getModulesCmdPath)
+			if err == nil && !getModulesCmdReveal {
+				mta.RedactModules(modules)
+			}
+			return modules, err
 		})
 	},
 	Hidden:        true,
@@ -21,6 +21,15 @@ var updateBuildParametersCmdPath string
 var updateBuildParametersCmdData string
 var updateBuildParametersCmdForce bool
 var updateBuildParametersCmdHashcode int
+var deployOrderCmdPath string
+var graphCmdPath string
+var graphCmdModule string
+var graphCmdFormat string
+var impactCmdPath string
+var impactCmdExtensions []string
+var impactCmdReference string
+var expandCmdPath string
+var expandCmdExtensions []string
 
 func init() {
 
@@ -47,6 +56,24 @@ func init() {
 		"force action")
 	updateBuildParametersCmd.Flags().IntVarP(&updateBuildParametersCmdHashcode, "hashcode", "c", 0,
 		"data hashcode")
+	deployOrderCmd.Flags().StringVarP(&deployOrderCmdPath, "path", "p", "",
+		"the path to the yaml file")
+	graphCmd.Flags().StringVarP(&graphCmdPath, "path", "p", "",
+		"the path to the yaml file")
+	graphCmd.Flags().StringVarP(&graphCmdModule, "module", "m", "",
+		"the name of the module; only the modules, resources and provided property sets it depends on are included")
+	graphCmd.Flags().StringVarP(&graphCmdFormat, "format", "f", mta.DOTGraphFormat,
+		"the output format: dot, mermaid or json")
+	impactCmd.Flags().StringVarP(&impactCmdPath, "path", "p", "",
+		"the path to the yaml file")
+	impactCmd.Flags().StringSliceVarP(&impactCmdExtensions, "extensions", "x", nil,
+		"the paths to the MTA extension descriptors")
+	impactCmd.Flags().StringVarP(&impactCmdReference, "reference", "r", "",
+		`the name of a resource or a provided property set, or a "<provider>/<property>" pair`)
+	expandCmd.Flags().StringVarP(&expandCmdPath, "path", "p", "",
+		"the path to the yaml file")
+	expandCmd.Flags().StringSliceVarP(&expandCmdExtensions, "extensions", "x", nil,
+		"the paths to the MTA extension descriptors")
 }
 
 // createMtaCmd Create new MTA project
@@ -141,3 +168,90 @@ var updateBuildParametersCmd = &cobra.Command{
 	SilenceUsage:  true,
 	SilenceErrors: true,
 }
+
+// deployOrderCmd gets the deployment order of the modules
+var deployOrderCmd = &cobra.Command{
+	Use:   "deploy-order",
+	Short: "Get the deployment order of the modules",
+	Long: `Get the deployment order of the modules as a list of waves; the modules of each wave are deployed in parallel
+after the modules of the previous waves. When the "enable-parallel-deployments" MTA parameter is true, a module
+is deployed after the modules in its "deployed-after" list; otherwise the modules are deployed one by one.
+Cycles and references to unknown modules in "deployed-after" are reported with their line numbers`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return mta.RunAndWriteResultAndHash("get deployment order", deployOrderCmdPath, func() (interface{}, error) {
+			return mta.GetDeploymentOrder(deployOrderCmdPath)
+		})
+	},
+	Hidden:        false,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// graphCmd prints the dependency graph of the MTA
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Print the dependency graph of the MTA",
+	Long: `Print the dependency graph of the modules, resources, provided property sets and hooks of the MTA,
+built from their requires, provides, hooks and "deployed-after" definitions. The graph is printed
+in Graphviz DOT format, as a Mermaid flowchart or as a JSON adjacency list`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logs.Logger.Info("get dependency graph")
+		graph, err := mta.GetGraph(graphCmdPath, graphCmdModule, graphCmdFormat)
+		if err != nil {
+			logs.Logger.Error(err)
+			return err
+		}
+		fmt.Print(graph)
+		return nil
+	},
+	Hidden:        false,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// impactCmd gets the usages of a resource, a provided property set or a provided property
+var impactCmd = &cobra.Command{
+	Use:   "impact",
+	Short: "Get the usages of a resource or a provided property",
+	Long: `Get the modules, hooks and resources affected by a change of a resource, a provided property set
+or a provided property: their requires entries and the ~{...} variables in their property and parameter values
+which refer to it, with the file and line of each usage`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return mta.RunAndWriteResultAndHash("get usages", impactCmdPath, func() (interface{}, error) {
+			return mta.GetUsages(impactCmdPath, impactCmdExtensions, impactCmdReference)
+		})
+	},
+	Hidden:        false,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// expandCmd prints the MTA with the module types and resource types applied to the modules and resources
+var expandCmd = &cobra.Command{
+	Use:   "expand",
+	Short: "Print the MTA with its module types and resource types applied",
+	Long: `Print the MTA, merged with the MTA extension descriptors, after the properties and parameters
+of the module types and resource types declared in it, including the ones inherited through "extends",
+are added to the modules and resources of these types`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logs.Logger.Info("expand module types and resource types")
+		expanded, err := mta.GetExpandedMta(expandCmdPath, expandCmdExtensions)
+		if err == nil {
+			var content []byte
+			content, err = mta.Marshal(expanded)
+			if err == nil {
+				fmt.Print(string(content))
+				return nil
+			}
+		}
+		logs.Logger.Error(err)
+		return err
+	},
+	Hidden:        false,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"os"
 
 	. "github.com/onsi/ginkgo"
@@ -42,3 +43,144 @@ var _ = Describe("Resource", func() {
 		Ω(createMtaCmd.RunE(nil, []string{})).Should(HaveOccurred())
 	})
 })
+
+var _ = Describe("Deploy order", func() {
+	It("Sanity", func() {
+		deployOrderCmdPath = getTestPath("mta.yaml")
+		Ω(deployOrderCmd.RunE(nil, []string{})).Should(Succeed())
+	})
+	It("Fails when the file does not exist", func() {
+		deployOrderCmdPath = getTestPath("result", "mta.yaml")
+		Ω(deployOrderCmd.RunE(nil, []string{})).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("Graph", func() {
+	It("Sanity", func() {
+		graphCmdPath = getTestPath("mta.yaml")
+		graphCmdModule = ""
+		graphCmdFormat = mta.MermaidGraphFormat
+		Ω(graphCmd.RunE(nil, []string{})).Should(Succeed())
+	})
+	It("Fails on an unknown format", func() {
+		graphCmdPath = getTestPath("mta.yaml")
+		graphCmdFormat = "svg"
+		Ω(graphCmd.RunE(nil, []string{})).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("Impact", func() {
+	It("Sanity", func() {
+		impactCmdPath = getTestPath("mta.yaml")
+		impactCmdExtensions = nil
+		impactCmdReference = "backend"
+		Ω(impactCmd.RunE(nil, []string{})).Should(Succeed())
+	})
+	It("Fails on an empty reference", func() {
+		impactCmdPath = getTestPath("mta.yaml")
+		impactCmdReference = ""
+		Ω(impactCmd.RunE(nil, []string{})).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("Expand", func() {
+	It("Sanity", func() {
+		expandCmdPath = getTestPath("mta.yaml")
+		expandCmdExtensions = nil
+		Ω(expandCmd.RunE(nil, []string{})).Should(Succeed())
+	})
+	It("Fails on a missing extension file", func() {
+		expandCmdPath = getTestPath("mta.yaml")
+		expandCmdExtensions = []string{getTestPath("missing.mtaext")}
+		Ω(expandCmd.RunE(nil, []string{})).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("Validate", func() {
+	BeforeEach(func() {
+		validateCmdPath = getTestPath("mta.yaml")
+		validateCmdMode = "schema"
+		validateCmdStrict = false
+		validateCmdExclude = ""
+		validateCmdSchemaVersion = ""
+	})
+	It("Sanity", func() {
+		Ω(validateCmd.RunE(nil, []string{})).Should(Succeed())
+	})
+	It("Fails on an unsupported schema version", func() {
+		validateCmdSchemaVersion = "4.0"
+		err := validateCmd.RunE(nil, []string{})
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(`the "4.0" schema version is not supported`))
+	})
+	It("Fails on an unknown validation mode", func() {
+		validateCmdMode = "full"
+		Ω(validateCmd.RunE(nil, []string{})).Should(HaveOccurred())
+	})
+	It("Applies the fixes", func() {
+		os.MkdirAll(getTestPath("result"), os.ModePerm)
+		defer os.RemoveAll(getTestPath("result"))
+		validateCmdPath = getTestPath("result", "mta.yaml")
+		Ω(ioutil.WriteFile(validateCmdPath, []byte(fixableMta), 0644)).Should(Succeed())
+		validateCmdMode = ""
+		validateCmdFix = true
+		defer func() {
+			validateCmdFix = false
+		}()
+		Ω(validateCmd.RunE(nil, []string{})).Should(Succeed())
+		content, err := ioutil.ReadFile(validateCmdPath)
+		Ω(err).Should(Succeed())
+		Ω(string(content)).Should(ContainSubstring("no-source: true # the module has no sources"))
+	})
+})
+
+const fixableMta = `_schema-version: "3.2"
+ID: fixable
+version: 1.0.0
+modules:
+  - name: a
+    type: java
+    path: a
+    build-parameters:
+      no-source: "true" # the module has no sources
+`
+
+var _ = Describe("CodeActions", func() {
+	BeforeEach(func() {
+		os.MkdirAll(getTestPath("result"), os.ModePerm)
+		codeActionsCmdPath = getTestPath("result", "mta.yaml")
+		Ω(ioutil.WriteFile(codeActionsCmdPath, []byte(fixableMta), 0644)).Should(Succeed())
+	})
+	AfterEach(func() {
+		os.RemoveAll(getTestPath("result"))
+	})
+	It("Sanity", func() {
+		Ω(codeActionsCmd.RunE(nil, []string{})).Should(Succeed())
+	})
+	It("Fails when the file does not exist", func() {
+		codeActionsCmdPath = getTestPath("result", "mta1.yaml")
+		Ω(codeActionsCmd.RunE(nil, []string{})).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("Migrate", func() {
+	BeforeEach(func() {
+		os.MkdirAll(getTestPath("result"), os.ModePerm)
+		migrateCmdPath = getTestPath("result", "mta.yaml")
+		Ω(mta.CopyFile(getTestPath("mta.yaml"), migrateCmdPath, os.Create)).Should(Succeed())
+	})
+	AfterEach(func() {
+		os.RemoveAll(getTestPath("result"))
+	})
+	It("Sanity", func() {
+		migrateCmdTo = "3.3"
+		Ω(migrateCmd.RunE(nil, []string{})).Should(Succeed())
+		content, err := ioutil.ReadFile(migrateCmdPath)
+		Ω(err).Should(Succeed())
+		Ω(string(content)).Should(ContainSubstring(`_schema-version: "3.3"`))
+	})
+	It("Fails on an unsupported schema version", func() {
+		migrateCmdTo = "4.0"
+		Ω(migrateCmd.RunE(nil, []string{})).Should(HaveOccurred())
+	})
+})
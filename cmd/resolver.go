@@ -1,8 +1,10 @@
 package commands
 
 import (
+	"os"
+
 	"github.com/SAP/cloud-mta/internal/logs"
-	"github.com/SAP/cloud-mta/internal/resolver"
+	"github.com/SAP/cloud-mta/resolver"
 	"github.com/spf13/cobra"
 )
 
@@ -10,6 +12,9 @@ var resolvePath string
 var workspaceDir string
 var resolveModule string
 var resolveEnvFileName string
+var resolveExtensions []string
+var resolveAll bool
+var resolveReveal bool
 
 func init() {
 	resolveMtaCmd.Flags().StringVarP(&resolvePath, "path", "p", "",
@@ -20,6 +25,12 @@ func init() {
 		"module-name")
 	resolveMtaCmd.Flags().StringVarP(&resolveEnvFileName, "envFile", "e", "",
 		"the environment file name. The default file name is .env")
+	resolveMtaCmd.Flags().StringSliceVarP(&resolveExtensions, "extensions", "x", nil,
+		"the MTA extension descriptors, applied to the MTA file before the resolution")
+	resolveMtaCmd.Flags().BoolVarP(&resolveAll, "all", "a", false,
+		"resolve the whole MTA file and print it in YAML format, instead of the environment of a single module")
+	resolveMtaCmd.Flags().BoolVarP(&resolveReveal, "reveal", "r", false,
+		"print the values of the sensitive properties and parameters instead of redacting them")
 
 }
 
@@ -29,11 +40,22 @@ var resolveMtaCmd = &cobra.Command{
 	Short: "Resolve variables and placeholders in an MTA file",
 	Long: `MTA file typically contains variables in the form ~{var-name} and placeholders in the form ${placeholder}, 
 resolve command print to stdout the MTA fil contents with as much as possible variables and placeholders replaced 
-with concrete values, based on environment variables provided and environment files in the modules' folders`,
+with concrete values, based on environment variables provided and environment files in the modules' folders.
+MTA extension descriptors provided with the extensions flag are applied to the MTA file before the resolution.
+With the all flag, the parameters, properties, requires, provides and hooks of all the modules and resources
+are resolved and the whole resolved MTA file is printed.
+The values of the properties and parameters marked as sensitive in their metadata are redacted,
+unless the reveal flag is provided`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		logs.Logger.Info("Resolve MTA")
-		err := resolver.Resolve(workspaceDir, resolveModule, resolvePath, resolveEnvFileName)
+		var err error
+		env := resolver.EnvironmentFromList(os.Environ())
+		if resolveAll {
+			err = resolver.ResolveMTA(workspaceDir, resolvePath, resolveExtensions, resolveEnvFileName, env, resolveReveal)
+		} else {
+			err = resolver.Resolve(workspaceDir, resolveModule, resolvePath, resolveExtensions, resolveEnvFileName, env, resolveReveal)
+		}
 		if err != nil {
 			logs.Logger.Error(err)
 		}
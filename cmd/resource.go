@@ -11,6 +11,7 @@ var addResourceCmdData string
 var addResourceCmdForce bool
 var addResourceCmdHashcode int
 var getResourcesCmdPath string
+var getResourcesCmdReveal bool
 var updateResourceMtaCmdPath string
 var updateResourceCmdData string
 var updateResourceCmdHashcode int
@@ -27,6 +28,8 @@ func init() {
 		"data hashcode")
 	getResourcesCmd.Flags().StringVarP(&getResourcesCmdPath, "path", "p", "",
 		"the path to the yaml file")
+	getResourcesCmd.Flags().BoolVarP(&getResourcesCmdReveal, "reveal", "r", false,
+		"print the values of the sensitive properties and parameters instead of redacting them")
 	updateResourceCmd.Flags().StringVarP(&updateResourceMtaCmdPath, "path", "p", "",
 		"the path to the yaml file")
 	updateResourceCmd.Flags().StringVarP(&updateResourceCmdData, "data", "d", "",
@@ -59,7 +62,11 @@ var getResourcesCmd = &cobra.Command{
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return mta.RunAndWriteResultAndHash("get resources", getResourcesCmdPath, func() (interface{}, error) {
-			return mta.GetResources(getResourcesCmdPath)
+			resources, err := mta.GetResources(getResourcesCmdPath)
+			if err == nil && !getResourcesCmdReveal {
+				mta.RedactResources(resources)
+			}
+			return resources, err
 		})
 	},
 	Hidden:        true,
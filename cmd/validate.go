@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SAP/cloud-mta/internal/logs"
+	"github.com/SAP/cloud-mta/mta"
+	"github.com/SAP/cloud-mta/validations"
+)
+
+var validateCmdPath string
+var validateCmdMode string
+var validateCmdStrict bool
+var validateCmdExclude string
+var validateCmdSchemaVersion string
+var validateCmdFix bool
+
+func init() {
+	validateCmd.Flags().StringVarP(&validateCmdPath, "path", "p", "",
+		"the path to the yaml file")
+	validateCmd.Flags().StringVarP(&validateCmdMode, "mode", "m", "",
+		`the validation mode: "schema" or "semantic"; the semantic mode includes the schema validations`)
+	validateCmd.Flags().BoolVarP(&validateCmdStrict, "strict", "s", true,
+		"report the non-strict issues as errors instead of warnings")
+	validateCmd.Flags().StringVarP(&validateCmdExclude, "exclude", "e", "",
+		"a comma-separated list of the semantic validations to exclude")
+	validateCmd.Flags().StringVarP(&validateCmdSchemaVersion, "schema-version", "v", "",
+		`the schema version to validate with, instead of the "_schema-version" of the MTA`)
+	validateCmd.Flags().BoolVarP(&validateCmdFix, "fix", "f", false,
+		"apply the quick fixes of the issues to the MTA file before it is validated")
+}
+
+// validateCmd validates the MTA file
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the MTA file",
+	Long: `Validate the MTA file against the schema and the semantic rules. The schema is selected by
+the "_schema-version" of the MTA, unless the schema-version flag is provided. With the fix flag,
+the quick fixes of the issues are applied to the MTA file, preserving its comments, and a report
+of the applied fixes is printed before the remaining issues are reported. The severity, the strictness
+and the ignored paths of the rules are configured in the ".mtavalidate.yaml" file of the project folder,
+and a "# mta-validate-disable-next-line <rule>" comment suppresses the issues of the next line.
+The "custom-rules" of the file declare the conditions the selected modules and resources must meet,
+and its "types" list the types definition files whose module types and resource types are checked
+in addition to the built-in types`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logs.Logger.Info("validate MTA")
+		validateSchema, validateSemantic, err := validate.GetValidationMode(validateCmdMode)
+		if err == nil && validateCmdFix {
+			var report []string
+			report, err = validate.FixMtaYaml(filepath.Dir(validateCmdPath), filepath.Base(validateCmdPath),
+				validateCmdSchemaVersion, validateSchema, validateSemantic, validateCmdStrict, validateCmdExclude)
+			for _, line := range report {
+				fmt.Println(line)
+			}
+		}
+		if err == nil {
+			var warning string
+			warning, err = validate.MtaYamlWithSchemaVersion(filepath.Dir(validateCmdPath), filepath.Base(validateCmdPath),
+				validateCmdSchemaVersion, validateSchema, validateSemantic, validateCmdStrict, validateCmdExclude)
+			if len(warning) > 0 {
+				logs.Logger.Warn(warning)
+			}
+		}
+		if err != nil {
+			logs.Logger.Error(err)
+		}
+		return err
+	},
+	Hidden:        false,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+var migrateCmdPath string
+var migrateCmdTo string
+
+func init() {
+	migrateCmd.Flags().StringVarP(&migrateCmdPath, "path", "p", "",
+		"the path to the yaml file")
+	migrateCmd.Flags().StringVarP(&migrateCmdTo, "to", "t", "",
+		"the schema version to migrate to")
+}
+
+// migrateCmd migrates the MTA file to a schema version
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate the MTA file to a schema version",
+	Long: `Migrate the MTA file to a schema version: the "_schema-version" is updated and the deprecated
+build options are converted to the "custom" builder. Only the migrated parts of the file are changed,
+so its comments and formatting are preserved. The file is not changed when the migrated MTA is not valid
+against the schema of the target version; otherwise, a report of the transformations applied is printed`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logs.Logger.Info("migrate MTA")
+		report, err := validate.Migrate(migrateCmdPath, migrateCmdTo)
+		if err != nil {
+			logs.Logger.Error(err)
+			return err
+		}
+		for _, line := range report {
+			fmt.Println(line)
+		}
+		return nil
+	},
+	Hidden:        false,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+var codeActionsCmdPath string
+var codeActionsCmdURI string
+var codeActionsCmdStrict bool
+var codeActionsCmdExclude string
+var codeActionsCmdSchemaVersion string
+
+func init() {
+	codeActionsCmd.Flags().StringVarP(&codeActionsCmdPath, "path", "p", "",
+		"the path to the yaml file")
+	codeActionsCmd.Flags().StringVarP(&codeActionsCmdURI, "uri", "u", "",
+		"the URI of the document in the editor; the file URI of the yaml file is used by default")
+	codeActionsCmd.Flags().BoolVarP(&codeActionsCmdStrict, "strict", "s", true,
+		"report the non-strict issues as errors instead of warnings")
+	codeActionsCmd.Flags().StringVarP(&codeActionsCmdExclude, "exclude", "e", "",
+		"a comma-separated list of the semantic validations to exclude")
+	codeActionsCmd.Flags().StringVarP(&codeActionsCmdSchemaVersion, "schema-version", "v", "",
+		`the schema version to validate with, instead of the "_schema-version" of the MTA`)
+}
+
+// codeActionsCmd gets the quick fixes of the validation issues as code actions of the Language Server Protocol
+var codeActionsCmd = &cobra.Command{
+	Use:   "code-actions",
+	Short: "Get the quick fixes of the validation issues",
+	Long:  "Get the quick fixes of the validation issues of the MTA file as code actions of the Language Server Protocol",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return mta.RunAndWriteResultAndHash("get code actions", codeActionsCmdPath, func() (interface{}, error) {
+			return validate.GetCodeActions(filepath.Dir(codeActionsCmdPath), filepath.Base(codeActionsCmdPath), codeActionsCmdURI,
+				codeActionsCmdSchemaVersion, codeActionsCmdStrict, codeActionsCmdExclude)
+		})
+	},
+	Hidden:        true,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
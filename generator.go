@@ -1,5 +1,10 @@
 package main
 
+//go:generate go run ./tools/embed.go -source=./validations/schema/schema_v2.1.yaml -target=./validations/mta_schema_v2_1.go -name=schemaV21Def -package=validate
+//go:generate go run ./tools/embed.go -source=./validations/schema/schema_v3.1.yaml -target=./validations/mta_schema_v3_1.go -name=schemaV31Def -package=validate
 //go:generate go run ./tools/embed.go -source=./validations/schema/schema_v3.2.yaml -target=./validations/mta_schema.go -name=schemaDef -package=validate
+//go:generate go run ./tools/embed.go -source=./validations/schema/schema_v3.3.yaml -target=./validations/mta_schema_v3_3.go -name=schemaV33Def -package=validate
 //go:generate go run ./tools/embed.go -source=./validations/schema/mtaext-schema_v3.2.yaml -target=./validations/mtaext_schema.go -name=extSchemaDef -package=validate
 //go:generate go run ./tools/embed.go -source=./configs/version.yaml -target=./internal/version/version_cfg.go -name=VersionConfig -package=version
+//go:generate go run ./tools/embed.go -source=./validations/types/builtin_types.yaml -target=./validations/builtin_types.go -name=builtinTypesDef -package=validate
+//go:generate go run ./tools/jsonschema/jsonschema.go -source=./validations/schema/schema_v3.3.yaml -target=./lsp/schema.json
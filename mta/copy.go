@@ -0,0 +1,129 @@
+package mta
+
+// Copy returns a deep copy of the MTA, so that changing the copy, for example by resolving it,
+// does not change the original MTA
+func (mta *MTA) Copy() *MTA {
+	result := *mta
+	if mta.SchemaVersion != nil {
+		schemaVersion := *mta.SchemaVersion
+		result.SchemaVersion = &schemaVersion
+	}
+	result.Parameters, result.ParametersMetaData = copyMap(mta.Parameters), copyMetaData(mta.ParametersMetaData)
+	if mta.Modules != nil {
+		result.Modules = make([]*Module, len(mta.Modules))
+		for i, module := range mta.Modules {
+			result.Modules[i] = module.copy()
+		}
+	}
+	if mta.ModuleTypes != nil {
+		result.ModuleTypes = make([]*ModuleTypes, len(mta.ModuleTypes))
+		for i, moduleType := range mta.ModuleTypes {
+			copied := *moduleType
+			copied.Properties, copied.PropertiesMetaData = copyMap(moduleType.Properties), copyMetaData(moduleType.PropertiesMetaData)
+			copied.Parameters, copied.ParametersMetaData = copyMap(moduleType.Parameters), copyMetaData(moduleType.ParametersMetaData)
+			result.ModuleTypes[i] = &copied
+		}
+	}
+	if mta.Resources != nil {
+		result.Resources = make([]*Resource, len(mta.Resources))
+		for i, resource := range mta.Resources {
+			result.Resources[i] = resource.copy()
+		}
+	}
+	if mta.ResourceTypes != nil {
+		result.ResourceTypes = make([]*ResourceTypes, len(mta.ResourceTypes))
+		for i, resourceType := range mta.ResourceTypes {
+			copied := *resourceType
+			copied.Properties, copied.PropertiesMetaData = copyMap(resourceType.Properties), copyMetaData(resourceType.PropertiesMetaData)
+			copied.Parameters, copied.ParametersMetaData = copyMap(resourceType.Parameters), copyMetaData(resourceType.ParametersMetaData)
+			result.ResourceTypes[i] = &copied
+		}
+	}
+	if mta.BuildParams != nil {
+		buildParams := ProjectBuild{
+			BeforeAll: copyProjectBuilders(mta.BuildParams.BeforeAll),
+			AfterAll:  copyProjectBuilders(mta.BuildParams.AfterAll),
+		}
+		result.BuildParams = &buildParams
+	}
+	return &result
+}
+
+func (module *Module) copy() *Module {
+	result := *module
+	result.Properties, result.PropertiesMetaData = copyMap(module.Properties), copyMetaData(module.PropertiesMetaData)
+	result.Parameters, result.ParametersMetaData = copyMap(module.Parameters), copyMetaData(module.ParametersMetaData)
+	result.BuildParams = copyMap(module.BuildParams)
+	result.Includes = copyIncludes(module.Includes)
+	result.Requires = copyRequires(module.Requires)
+	if module.Provides != nil {
+		result.Provides = make([]Provides, len(module.Provides))
+		for i, provides := range module.Provides {
+			provides.Properties, provides.PropertiesMetaData = copyMap(provides.Properties), copyMetaData(provides.PropertiesMetaData)
+			result.Provides[i] = provides
+		}
+	}
+	if module.DeployedAfter != nil {
+		result.DeployedAfter = append([]string{}, module.DeployedAfter...)
+	}
+	if module.Hooks != nil {
+		result.Hooks = make([]Hook, len(module.Hooks))
+		for i, hook := range module.Hooks {
+			if hook.Phases != nil {
+				hook.Phases = append([]string{}, hook.Phases...)
+			}
+			hook.Parameters, hook.ParametersMetaData = copyMap(hook.Parameters), copyMetaData(hook.ParametersMetaData)
+			hook.Requires = copyRequires(hook.Requires)
+			result.Hooks[i] = hook
+		}
+	}
+	return &result
+}
+
+func (resource *Resource) copy() *Resource {
+	result := *resource
+	result.Properties, result.PropertiesMetaData = copyMap(resource.Properties), copyMetaData(resource.PropertiesMetaData)
+	result.Parameters, result.ParametersMetaData = copyMap(resource.Parameters), copyMetaData(resource.ParametersMetaData)
+	result.Includes = copyIncludes(resource.Includes)
+	result.Requires = copyRequires(resource.Requires)
+	if resource.Active != nil {
+		active := *resource.Active
+		result.Active = &active
+	}
+	return &result
+}
+
+func copyRequires(requires []Requires) []Requires {
+	if requires == nil {
+		return nil
+	}
+	result := make([]Requires, len(requires))
+	for i, req := range requires {
+		req.Properties, req.PropertiesMetaData = copyMap(req.Properties), copyMetaData(req.PropertiesMetaData)
+		req.Parameters, req.ParametersMetaData = copyMap(req.Parameters), copyMetaData(req.ParametersMetaData)
+		req.Includes = copyIncludes(req.Includes)
+		result[i] = req
+	}
+	return result
+}
+
+func copyIncludes(includes []Includes) []Includes {
+	if includes == nil {
+		return nil
+	}
+	return append([]Includes{}, includes...)
+}
+
+func copyProjectBuilders(builders []ProjectBuilder) []ProjectBuilder {
+	if builders == nil {
+		return nil
+	}
+	result := make([]ProjectBuilder, len(builders))
+	for i, builder := range builders {
+		if builder.Commands != nil {
+			builder.Commands = append([]string{}, builder.Commands...)
+		}
+		result[i] = builder
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package mta
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Copy", func() {
+	It("returns a deep copy of the MTA", func() {
+		content, err := readFile(getTestPath("mta.yaml"))
+		Ω(err).Should(Succeed())
+		original, err := Unmarshal(content)
+		Ω(err).Should(Succeed())
+		expected, err := Unmarshal(content)
+		Ω(err).Should(Succeed())
+
+		copied := original.Copy()
+		Ω(copied).Should(Equal(original))
+
+		for _, module := range copied.Modules {
+			module.Name = "changed"
+			for key := range module.Properties {
+				module.Properties[key] = "changed"
+			}
+			for key := range module.Parameters {
+				module.Parameters[key] = "changed"
+			}
+			for i := range module.Requires {
+				module.Requires[i].Properties = map[string]interface{}{"added": "value"}
+			}
+			for _, provides := range module.Provides {
+				for key := range provides.Properties {
+					provides.Properties[key] = "changed"
+				}
+			}
+		}
+		for _, resource := range copied.Resources {
+			resource.Name = "changed"
+			for key := range resource.Parameters {
+				resource.Parameters[key] = "changed"
+			}
+		}
+		copied.Parameters = map[string]interface{}{"added": "value"}
+		Ω(original).Should(Equal(expected))
+	})
+
+	It("copies the nested maps and lists of the values", func() {
+		original := &MTA{Parameters: map[string]interface{}{
+			"map":  map[string]interface{}{"a": "b"},
+			"list": []interface{}{"a"},
+		}}
+		copied := original.Copy()
+		copied.Parameters["map"].(map[string]interface{})["a"] = "changed"
+		copied.Parameters["list"].([]interface{})[0] = "changed"
+		Ω(original.Parameters["map"]).Should(Equal(map[string]interface{}{"a": "b"}))
+		Ω(original.Parameters["list"]).Should(Equal([]interface{}{"a"}))
+	})
+})
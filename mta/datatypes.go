@@ -0,0 +1,70 @@
+package mta
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The datatypes of the properties and parameters, declared in the "datatype" field of their metadata
+const (
+	StrDatatype   = "str"
+	IntDatatype   = "int"
+	FloatDatatype = "float"
+	BoolDatatype  = "bool"
+)
+
+const (
+	wrongDatatypeErrorMsg = `the value of the "%s" field does not match its "%s" datatype`
+
+	placeholderPrefix = "${"
+)
+
+// CheckDatatype checks that the value of the field matches the datatype declared in its metadata.
+// Empty values, values of fields without a declared datatype or with an unknown datatype, and values which
+// contain placeholders or variables (which are only known after they are resolved) are not checked.
+func CheckDatatype(field string, meta map[string]MetaData, value interface{}) error {
+	metaData, ok := meta[field]
+	if !ok || metaData.Datatype == nil || value == nil {
+		return nil
+	}
+	datatype, ok := metaData.Datatype.(string)
+	if !ok || IsDatatypeValue(datatype, value) {
+		return nil
+	}
+	return errors.Errorf(wrongDatatypeErrorMsg, field, datatype)
+}
+
+// IsDatatypeValue returns false if the value does not match the datatype. An int value matches the "float" datatype.
+// An unknown datatype matches any value.
+func IsDatatypeValue(datatype string, value interface{}) bool {
+	if s, ok := value.(string); ok && datatype != StrDatatype &&
+		(strings.Contains(s, placeholderPrefix) || strings.Contains(s, variablePrefix)) {
+		return true
+	}
+	switch datatype {
+	case StrDatatype:
+		_, ok := value.(string)
+		return ok
+	case IntDatatype:
+		return isIntValue(value)
+	case FloatDatatype:
+		switch value.(type) {
+		case float32, float64:
+			return true
+		}
+		return isIntValue(value)
+	case BoolDatatype:
+		_, ok := value.(bool)
+		return ok
+	}
+	return true
+}
+
+func isIntValue(value interface{}) bool {
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return true
+	}
+	return false
+}
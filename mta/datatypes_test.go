@@ -0,0 +1,58 @@
+package mta
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Datatypes", func() {
+	DescribeTable("IsDatatypeValue", func(datatype string, value interface{}, expected bool) {
+		Ω(IsDatatypeValue(datatype, value)).Should(Equal(expected))
+	},
+		Entry("string is str", StrDatatype, "a", true),
+		Entry("int is not str", StrDatatype, 1, false),
+		Entry("int is int", IntDatatype, 1, true),
+		Entry("float is not int", IntDatatype, 1.5, false),
+		Entry("string is not int", IntDatatype, "1", false),
+		Entry("placeholder is int", IntDatatype, "${port}", true),
+		Entry("variable is bool", BoolDatatype, "~{srv/debug}", true),
+		Entry("float is float", FloatDatatype, 1.5, true),
+		Entry("int is float", FloatDatatype, 1, true),
+		Entry("bool is bool", BoolDatatype, false, true),
+		Entry("string is not bool", BoolDatatype, "true", false),
+		Entry("unknown datatype", "date", "2019-01-01", true),
+	)
+
+	It("CheckDatatype returns an error on a value which does not match the datatype", func() {
+		meta := map[string]MetaData{"port": {Datatype: IntDatatype}, "name": {}}
+		Ω(CheckDatatype("port", meta, 8080)).Should(Succeed())
+		Ω(CheckDatatype("port", meta, nil)).Should(Succeed())
+		Ω(CheckDatatype("name", meta, 1)).Should(Succeed())
+		Ω(CheckDatatype("other", meta, 1)).Should(Succeed())
+		err := CheckDatatype("port", meta, "8080")
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(`the value of the "port" field does not match its "int" datatype`))
+	})
+
+	It("Merge fails on an extension value which does not match the datatype", func() {
+		mta := &MTA{
+			ID: "mta",
+			Modules: []*Module{{
+				Name:               "srv",
+				Properties:         map[string]interface{}{"port": 8080},
+				PropertiesMetaData: map[string]MetaData{"port": {Datatype: IntDatatype}},
+			}},
+		}
+		ext := &EXT{ID: "ext", Extends: "mta", Modules: []*ModuleExt{{
+			Name:       "srv",
+			Properties: map[string]interface{}{"port": "http"},
+		}}}
+		err := Merge(mta, ext)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(`the value of the "port" field does not match its "int" datatype`))
+
+		ext.Modules[0].Properties["port"] = 9090
+		Ω(Merge(mta, ext)).Should(Succeed())
+	})
+})
@@ -0,0 +1,236 @@
+package mta
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	deployedAfterUnknownModuleMsg = `the "%s" module is deployed after the "%s" module, which is not defined`
+	deployedAfterItselfMsg        = `the "%s" module is deployed after itself`
+	deployedAfterCycleMsg         = `the "%s" module is deployed after the "%s" module in a cycle: %s`
+	duplicateModuleNameMsg        = `the "%s" module is defined more than once; the deployment order requires unique module names`
+	deploymentOrderFailsMsg       = `could not compute the deployment order of the "%s" file`
+	issueOnLineMsg                = `line %d: %s`
+
+	// EnableParallelDeploymentsParam is the MTA parameter which enables the deployment of modules in parallel
+	EnableParallelDeploymentsParam = "enable-parallel-deployments"
+
+	deploymentCycleSeparator = " -> "
+)
+
+// DeploymentOrderIssue is a problem in the "deployed-after" dependencies of a module, which prevents the computation
+// of the deployment order. Module is the index of the module and DeployedAfter is the index of the entry in
+// the module's "deployed-after" list, or -1 if the issue is on the module itself.
+type DeploymentOrderIssue struct {
+	Msg           string
+	Module        int
+	DeployedAfter int
+}
+
+// IsParallelDeploymentEnabled returns true if the "enable-parallel-deployments" MTA parameter is set to true
+func (mta *MTA) IsParallelDeploymentEnabled() bool {
+	switch v := mta.Parameters[EnableParallelDeploymentsParam].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+// GetDeploymentOrder returns the deployment order of the modules, as a list of waves: the modules of each wave
+// are deployed in parallel after the modules of the previous waves were deployed. The modules in a wave keep
+// their order in the MTA.
+// When parallel deployment is enabled, a module is deployed after the modules in its "deployed-after" list;
+// otherwise, the modules are deployed one by one in their order in the MTA and "deployed-after" is ignored.
+// In both cases, modules with the same name, references to unknown modules, modules deployed after themselves
+// and cycles are returned as issues, and the deployment order is only returned if there are no issues.
+func (mta *MTA) GetDeploymentOrder() ([][]string, []DeploymentOrderIssue) {
+	issues := append(mta.getDuplicateModuleIssues(), mta.GetDeployedAfterIssues()...)
+	if len(issues) > 0 {
+		return nil, issues
+	}
+
+	var waves [][]string
+	if !mta.IsParallelDeploymentEnabled() {
+		for _, module := range mta.Modules {
+			waves = append(waves, []string{module.Name})
+		}
+		return waves, nil
+	}
+
+	deployed := make(map[string]bool)
+	for count := 0; count < len(mta.Modules); {
+		var wave []string
+		for _, module := range mta.Modules {
+			if !deployed[module.Name] && allDeployed(module.DeployedAfter, deployed) {
+				wave = append(wave, module.Name)
+			}
+		}
+		// The issues above prevent this, but an empty wave would never let the remaining modules be deployed
+		if len(wave) == 0 {
+			break
+		}
+		for _, name := range wave {
+			deployed[name] = true
+		}
+		count += len(wave)
+		waves = append(waves, wave)
+	}
+	return waves, nil
+}
+
+// getDuplicateModuleIssues returns the modules which have the name of a previous module
+func (mta *MTA) getDuplicateModuleIssues() []DeploymentOrderIssue {
+	var issues []DeploymentOrderIssue
+	names := make(map[string]bool)
+	for i, module := range mta.Modules {
+		if names[module.Name] {
+			issues = append(issues, DeploymentOrderIssue{fmt.Sprintf(duplicateModuleNameMsg, module.Name), i, -1})
+		}
+		names[module.Name] = true
+	}
+	return issues
+}
+
+func allDeployed(names []string, deployed map[string]bool) bool {
+	for _, name := range names {
+		if !deployed[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// GetDeployedAfterIssues returns the references to unknown modules, the modules deployed after themselves
+// and the cycles in the "deployed-after" dependencies of the modules
+func (mta *MTA) GetDeployedAfterIssues() []DeploymentOrderIssue {
+	var issues []DeploymentOrderIssue
+	modules := make(map[string]int)
+	for i, module := range mta.Modules {
+		if _, ok := modules[module.Name]; !ok {
+			modules[module.Name] = i
+		}
+	}
+
+	for i, module := range mta.Modules {
+		for j, name := range module.DeployedAfter {
+			if _, ok := modules[name]; !ok {
+				issues = append(issues, DeploymentOrderIssue{fmt.Sprintf(deployedAfterUnknownModuleMsg, module.Name, name), i, j})
+			} else if name == module.Name {
+				issues = append(issues, DeploymentOrderIssue{fmt.Sprintf(deployedAfterItselfMsg, module.Name), i, j})
+			}
+		}
+	}
+
+	// Find the cycles with a depth first search; a dependency on a module which is still being visited closes a cycle
+	const (
+		notVisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(mta.Modules))
+	var path []int
+	var visit func(i int)
+	visit = func(i int) {
+		state[i] = visiting
+		path = append(path, i)
+		module := mta.Modules[i]
+		for j, name := range module.DeployedAfter {
+			dep, ok := modules[name]
+			if !ok || dep == i {
+				continue
+			}
+			switch state[dep] {
+			case notVisited:
+				visit(dep)
+			case visiting:
+				var cycle []string
+				for k := len(path) - 1; k >= 0; k-- {
+					cycle = append([]string{mta.Modules[path[k]].Name}, cycle...)
+					if path[k] == dep {
+						break
+					}
+				}
+				cycle = append(cycle, name)
+				issues = append(issues, DeploymentOrderIssue{
+					fmt.Sprintf(deployedAfterCycleMsg, module.Name, name, strings.Join(cycle, deploymentCycleSeparator)), i, j})
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+	}
+	for i := range mta.Modules {
+		if state[i] == notVisited {
+			visit(i)
+		}
+	}
+	return issues
+}
+
+// GetDeploymentOrder returns the deployment order of the modules of the MTA in the path, as a list of waves.
+// The issues which prevent the computation of the deployment order are returned in the error, with their line numbers.
+func GetDeploymentOrder(path string) ([][]string, error) {
+	content, err := readMtaContent(path)
+	if err != nil {
+		return nil, err
+	}
+	mta, err := Unmarshal(content)
+	if err != nil {
+		return nil, err
+	}
+	waves, issues := mta.GetDeploymentOrder()
+	if len(issues) == 0 {
+		return waves, nil
+	}
+
+	var root yaml.Node
+	// The content was already unmarshalled successfully, so it can be parsed
+	_ = yaml.Unmarshal(content, &root)
+	messages := make([]string, len(issues))
+	for i, issue := range issues {
+		messages[i] = fmt.Sprintf(issueOnLineMsg, getDeployedAfterLine(&root, issue.Module, issue.DeployedAfter), issue.Msg)
+	}
+	return nil, errors.Wrapf(errors.New(strings.Join(messages, "\n")), deploymentOrderFailsMsg, path)
+}
+
+// getDeployedAfterLine returns the line of the entry in the "deployed-after" list of the module,
+// or the line of the module name if the entry index is negative
+func getDeployedAfterLine(root *yaml.Node, moduleIndex, deployedAfterIndex int) int {
+	modules := getMappingValue(root, "modules")
+	if modules == nil || modules.Kind != yaml.SequenceNode || moduleIndex >= len(modules.Content) {
+		return 0
+	}
+	module := modules.Content[moduleIndex]
+	if deployedAfterIndex < 0 {
+		if name := getMappingValue(module, "name"); name != nil {
+			return name.Line
+		}
+		return module.Line
+	}
+	deployedAfter := getMappingValue(module, "deployed-after")
+	if deployedAfter == nil || deployedAfter.Kind != yaml.SequenceNode || deployedAfterIndex >= len(deployedAfter.Content) {
+		return module.Line
+	}
+	return deployedAfter.Content[deployedAfterIndex].Line
+}
+
+// getMappingValue returns the value of the key in the mapping node, or nil if it is not found
+func getMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
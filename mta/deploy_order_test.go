@@ -0,0 +1,104 @@
+package mta
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetDeploymentOrder", func() {
+	It("returns the waves of modules deployed in parallel", func() {
+		waves, err := GetDeploymentOrder(getTestPath("deployOrder", "mta.yaml"))
+		Ω(err).Should(Succeed())
+		Ω(waves).Should(Equal([][]string{{"db", "jobs"}, {"backend"}, {"ui"}}))
+	})
+
+	It("deploys the modules one by one when parallel deployment is not enabled", func() {
+		mta := &MTA{
+			Modules: []*Module{
+				{Name: "ui", DeployedAfter: []string{"backend"}},
+				{Name: "backend"},
+			},
+		}
+		waves, issues := mta.GetDeploymentOrder()
+		Ω(issues).Should(BeEmpty())
+		Ω(waves).Should(Equal([][]string{{"ui"}, {"backend"}}))
+	})
+
+	It("enables parallel deployment with a string parameter", func() {
+		mta := &MTA{
+			Parameters: map[string]interface{}{EnableParallelDeploymentsParam: "true"},
+			Modules: []*Module{
+				{Name: "ui", DeployedAfter: []string{"backend"}},
+				{Name: "backend"},
+			},
+		}
+		waves, issues := mta.GetDeploymentOrder()
+		Ω(issues).Should(BeEmpty())
+		Ω(waves).Should(Equal([][]string{{"backend"}, {"ui"}}))
+	})
+
+	It("returns the issues of the deployed-after dependencies", func() {
+		mta := &MTA{
+			Parameters: map[string]interface{}{EnableParallelDeploymentsParam: true},
+			Modules: []*Module{
+				{Name: "ui", DeployedAfter: []string{"backend", "unknown"}},
+				{Name: "backend", DeployedAfter: []string{"jobs"}},
+				{Name: "jobs", DeployedAfter: []string{"ui", "jobs"}},
+			},
+		}
+		waves, issues := mta.GetDeploymentOrder()
+		Ω(waves).Should(BeNil())
+		Ω(issues).Should(Equal([]DeploymentOrderIssue{
+			{fmt.Sprintf(deployedAfterUnknownModuleMsg, "ui", "unknown"), 0, 1},
+			{fmt.Sprintf(deployedAfterItselfMsg, "jobs"), 2, 1},
+			{fmt.Sprintf(deployedAfterCycleMsg, "jobs", "ui", "ui -> backend -> jobs -> ui"), 2, 0},
+		}))
+	})
+
+	It("returns the modules with the same name as issues", func() {
+		mta := &MTA{
+			Parameters: map[string]interface{}{EnableParallelDeploymentsParam: true},
+			Modules: []*Module{
+				{Name: "db"},
+				{Name: "backend", DeployedAfter: []string{"db"}},
+				{Name: "db"},
+			},
+		}
+		waves, issues := mta.GetDeploymentOrder()
+		Ω(waves).Should(BeNil())
+		Ω(issues).Should(Equal([]DeploymentOrderIssue{
+			{fmt.Sprintf(duplicateModuleNameMsg, "db"), 2, -1},
+		}))
+	})
+
+	It("reports the modules with the same name with the line of their name", func() {
+		path := getTestPath("deployOrder", "mtaDuplicateModules.yaml")
+		_, err := GetDeploymentOrder(path)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(fmt.Sprintf(deploymentOrderFailsMsg, path) + ": " +
+			fmt.Sprintf(issueOnLineMsg, 13, fmt.Sprintf(duplicateModuleNameMsg, "db"))))
+	})
+
+	It("reports the issues with their line numbers", func() {
+		path := getTestPath("deployOrder", "mtaWrongDeployedAfter.yaml")
+		_, err := GetDeploymentOrder(path)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(fmt.Sprintf(deploymentOrderFailsMsg, path) + ": " +
+			fmt.Sprintf(issueOnLineMsg, 13, fmt.Sprintf(deployedAfterUnknownModuleMsg, "ui", "unknown")) + "\n" +
+			fmt.Sprintf(issueOnLineMsg, 24, fmt.Sprintf(deployedAfterItselfMsg, "jobs")) + "\n" +
+			fmt.Sprintf(issueOnLineMsg, 23, fmt.Sprintf(deployedAfterCycleMsg, "jobs", "ui", "ui -> backend -> jobs -> ui"))))
+	})
+
+	It("fails when the file does not exist", func() {
+		_, err := GetDeploymentOrder(getTestPath("deployOrder", "notExists.yaml"))
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("fails on a wrong deployed-after value", func() {
+		_, err := GetDeploymentOrder(getTestPath("mtaWrongDeployedAfter.yaml"))
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring("line 54: cannot unmarshal !!int `1` into []string"))
+	})
+})
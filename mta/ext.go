@@ -208,7 +208,7 @@ func isFieldOverWritable(field string, meta map[string]MetaData, m map[string]in
 	return true
 }
 
-// extendMap extends map with elements of mta extension map
+// extendMap extends map with elements of mta extension map; the merged values must match the datatypes in the metadata
 func extendMap(m *map[string]interface{}, meta map[string]MetaData, ext map[string]interface{}) error {
 	if ext != nil {
 		if *m == nil {
@@ -220,6 +220,10 @@ func extendMap(m *map[string]interface{}, meta map[string]MetaData, ext map[stri
 				if err != nil {
 					return err
 				}
+				err = CheckDatatype(key, meta, (*m)[key])
+				if err != nil {
+					return err
+				}
 			} else {
 				return errors.Errorf(overwriteNonOverwritableErrorMsg, key)
 			}
@@ -2437,7 +2437,7 @@ func checkResourceMergeFails(mtaResource Resource, mtaExtResource ResourceExt, m
 func checkExtendMap(m map[string]interface{}, ext map[string]interface{}, meta map[string]MetaData, expected map[string]interface{}) {
 	// We don't want to change the sent map
 	mCopy := copyMap(m)
-	metaBeforeExtendMap := copyMetaMap(meta)
+	metaBeforeExtendMap := copyMetaData(meta)
 	extBeforeExtendMap := copyMap(ext)
 
 	err := extendMap(&mCopy, meta, ext)
@@ -2450,7 +2450,7 @@ func checkExtendMap(m map[string]interface{}, ext map[string]interface{}, meta m
 func checkExtendMapFails(m map[string]interface{}, ext map[string]interface{}, meta map[string]MetaData, errorMsg string, args ...interface{}) {
 	// We don't want to change the sent map
 	mCopy := copyMap(m)
-	metaBeforeExtendMap := copyMetaMap(meta)
+	metaBeforeExtendMap := copyMetaData(meta)
 	extBeforeExtendMap := copyMap(ext)
 
 	err := extendMap(&mCopy, meta, ext)
@@ -2460,52 +2460,3 @@ func checkExtendMapFails(m map[string]interface{}, ext map[string]interface{}, m
 	Ω(ext).Should(Equal(extBeforeExtendMap))
 	// Note: mCopy might be changed even if extendMap fails, since the map is merged in-place
 }
-
-func copyMap(source map[string]interface{}) map[string]interface{} {
-	if source == nil {
-		return nil
-	}
-	result := make(map[string]interface{})
-	for key, value := range source {
-		if mValue, ok, converted := getMapValue(value); ok {
-			if converted {
-				result[key] = copyInterfaceMap(value.(map[interface{}]interface{}))
-			} else {
-				result[key] = copyMap(mValue)
-			}
-		} else {
-			result[key] = value
-		}
-	}
-	return result
-}
-
-func copyInterfaceMap(source map[interface{}]interface{}) map[interface{}]interface{} {
-	if source == nil {
-		return nil
-	}
-	result := make(map[interface{}]interface{})
-	for key, value := range source {
-		if mValue, ok, converted := getMapValue(value); ok {
-			if converted {
-				result[key] = copyInterfaceMap(value.(map[interface{}]interface{}))
-			} else {
-				result[key] = copyMap(mValue)
-			}
-		} else {
-			result[key] = value
-		}
-	}
-	return result
-}
-
-func copyMetaMap(source map[string]MetaData) map[string]MetaData {
-	if source == nil {
-		return nil
-	}
-	result := make(map[string]MetaData)
-	for key, value := range source {
-		result[key] = value
-	}
-	return result
-}
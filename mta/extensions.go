@@ -0,0 +1,101 @@
+package mta
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	readExtErrorMsg          = `could not read the "%s" MTA extension file`
+	unmarshalExtErrorMsg     = `could not unmarshal the "%s" MTA extension file`
+	duplicateExtIDErrorMsg   = `the "%s" and "%s" MTA extension files have the same ID "%s"`
+	duplicateExtendsErrorMsg = `the "%s" and "%s" MTA extension files both extend the "%s" ID`
+	extendsMtaIDErrorMsg     = `the "%s" MTA extension file extends the "%s" ID, which is not the ID of the MTA or of another MTA extension`
+	extendsItselfErrorMsg    = `the "%s" MTA extension file extends its own ID "%s"`
+)
+
+// extFile is an MTA extension together with the path it was read from
+type extFile struct {
+	ext  *EXT
+	path string
+}
+
+// MergeExtFiles reads the MTA extension files and merges them into the MTA object.
+// The extensions must form a single chain: one extension extends the MTA ID and each other extension extends the ID
+// of a previous extension. The extensions are merged in the order of the chain, regardless of the order of the paths.
+func MergeExtFiles(mta *MTA, extPaths []string) error {
+	var exts []extFile
+	for _, extPath := range extPaths {
+		content, err := ioutil.ReadFile(extPath)
+		if err != nil {
+			return errors.Wrapf(err, readExtErrorMsg, extPath)
+		}
+		s := strings.Replace(string(content), "\r\n", "\r", -1)
+		ext, err := UnmarshalExt([]byte(s))
+		if err != nil {
+			return errors.Wrapf(err, unmarshalExtErrorMsg, extPath)
+		}
+		exts = append(exts, extFile{ext, extPath})
+	}
+
+	sortedExts, err := sortExtFiles(mta.ID, exts)
+	if err != nil {
+		return err
+	}
+	for _, ext := range sortedExts {
+		if err = Merge(mta, ext.ext); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortExtFiles orders the extensions by their "extends" chain, starting from the extension that extends the MTA ID
+func sortExtFiles(mtaID string, exts []extFile) ([]extFile, error) {
+	byID := make(map[string]extFile)
+	byExtends := make(map[string]extFile)
+	for _, ext := range exts {
+		if prev, ok := byID[ext.ext.ID]; ok {
+			return nil, errors.Errorf(duplicateExtIDErrorMsg, prev.path, ext.path, ext.ext.ID)
+		}
+		byID[ext.ext.ID] = ext
+		if ext.ext.Extends == ext.ext.ID {
+			return nil, errors.Errorf(extendsItselfErrorMsg, ext.path, ext.ext.ID)
+		}
+		if prev, ok := byExtends[ext.ext.Extends]; ok {
+			return nil, errors.Errorf(duplicateExtendsErrorMsg, prev.path, ext.path, ext.ext.Extends)
+		}
+		byExtends[ext.ext.Extends] = ext
+	}
+
+	var sorted []extFile
+	for parentID := mtaID; ; {
+		ext, ok := byExtends[parentID]
+		if !ok {
+			break
+		}
+		sorted = append(sorted, ext)
+		parentID = ext.ext.ID
+	}
+
+	if len(sorted) != len(exts) {
+		// Some extensions are not in the chain: they extend an unknown ID, or the extensions extend each other in a cycle
+		for _, ext := range exts {
+			if !containsExtFile(sorted, ext) {
+				return nil, errors.Errorf(extendsMtaIDErrorMsg, ext.path, ext.ext.Extends)
+			}
+		}
+	}
+	return sorted, nil
+}
+
+func containsExtFile(exts []extFile, ext extFile) bool {
+	for _, e := range exts {
+		if e.ext == ext.ext {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package mta
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MergeExtFiles", func() {
+	It("merges the extensions in the order of their extends chain", func() {
+		m := &MTA{
+			ID: "mta",
+			Parameters: map[string]interface{}{
+				"p1": "mta",
+				"p2": "mta",
+			},
+		}
+		err := MergeExtFiles(m, []string{getTestPath("chain", "second.mtaext"), getTestPath("chain", "first.mtaext")})
+		Ω(err).Should(Succeed())
+		Ω(m.Parameters).Should(Equal(map[string]interface{}{
+			"p1": "first",
+			"p2": "second",
+		}))
+	})
+	It("does nothing when there are no extensions", func() {
+		m := &MTA{ID: "mta"}
+		Ω(MergeExtFiles(m, nil)).Should(Succeed())
+		Ω(m.Parameters).Should(BeNil())
+	})
+	It("fails when the extension file does not exist", func() {
+		path := getTestPath("chain", "notExist.mtaext")
+		err := MergeExtFiles(&MTA{ID: "mta"}, []string{path})
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(readExtErrorMsg, path)))
+	})
+	It("fails when the extension file is invalid", func() {
+		path := getTestPath("chain", "invalid.mtaext")
+		err := MergeExtFiles(&MTA{ID: "mta"}, []string{path})
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(unmarshalExtErrorMsg, path)))
+	})
+	It("fails when the extension does not extend the MTA", func() {
+		path := getTestPath("chain", "second.mtaext")
+		err := MergeExtFiles(&MTA{ID: "mta"}, []string{path})
+		Ω(err).Should(MatchError(fmt.Sprintf(extendsMtaIDErrorMsg, path, "first")))
+	})
+	It("fails when the merge fails", func() {
+		err := MergeExtFiles(&MTA{ID: "mta"}, []string{getTestPath("chain", "unknownModule.mtaext")})
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(unknownModuleErrorMsg, "unknown")))
+	})
+})
+
+var _ = Describe("sortExtFiles", func() {
+	ext := func(id, extends string) extFile {
+		return extFile{&EXT{ID: id, Extends: extends}, id + ".mtaext"}
+	}
+
+	It("fails when two extensions have the same ID", func() {
+		_, err := sortExtFiles("mta", []extFile{ext("ext1", "mta"), ext("ext1", "ext1")})
+		Ω(err).Should(MatchError(fmt.Sprintf(duplicateExtIDErrorMsg, "ext1.mtaext", "ext1.mtaext", "ext1")))
+	})
+	It("fails when two extensions extend the same ID", func() {
+		_, err := sortExtFiles("mta", []extFile{ext("ext1", "mta"), ext("ext2", "mta")})
+		Ω(err).Should(MatchError(fmt.Sprintf(duplicateExtendsErrorMsg, "ext1.mtaext", "ext2.mtaext", "mta")))
+	})
+	It("fails when an extension extends itself", func() {
+		_, err := sortExtFiles("mta", []extFile{ext("ext1", "ext1")})
+		Ω(err).Should(MatchError(fmt.Sprintf(extendsItselfErrorMsg, "ext1.mtaext", "ext1")))
+	})
+	It("fails when the extensions extend each other", func() {
+		_, err := sortExtFiles("mta", []extFile{ext("ext1", "mta"), ext("ext2", "ext3"), ext("ext3", "ext2")})
+		Ω(err).Should(MatchError(fmt.Sprintf(extendsMtaIDErrorMsg, "ext2.mtaext", "ext3")))
+	})
+})
@@ -0,0 +1,289 @@
+package mta
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The kinds of the graph nodes
+const (
+	ModuleNodeKind   = "module"
+	ResourceNodeKind = "resource"
+	ProvidesNodeKind = "provides"
+	HookNodeKind     = "hook"
+)
+
+// The kinds of the graph edges
+const (
+	RequiresEdgeKind      = "requires"
+	ProvidesEdgeKind      = "provides"
+	HookEdgeKind          = "hook"
+	DeployedAfterEdgeKind = "deployed-after"
+)
+
+// The graph output formats
+const (
+	DOTGraphFormat     = "dot"
+	MermaidGraphFormat = "mermaid"
+	JSONGraphFormat    = "json"
+)
+
+const (
+	graphNodeNotFoundMsg     = `the "%s" module is not defined`
+	unknownGraphFormatMsg    = `the "%s" graph format is not supported; use one of: %s`
+	graphFormatsSeparator    = ", "
+	graphNodeIDSeparator     = ":"
+	graphHookModuleSeparator = "/"
+)
+
+// GraphNode is a module, a resource, a provided property set or a hook of the MTA
+type GraphNode struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+// GraphEdge is a dependency between two nodes of the graph
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// Graph is the dependency graph of the MTA. A module points to its provided property sets and hooks,
+// to the provided property sets and resources it requires and to the modules it is deployed after.
+// A resource or a hook points to the provided property sets and resources it requires.
+// Requires which cannot be matched to a provided property set or a resource are not part of the graph.
+type Graph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// GetGraph returns the dependency graph of the MTA
+func (mta *MTA) GetGraph() *Graph {
+	g := &Graph{}
+	for _, module := range mta.Modules {
+		g.addNode(ModuleNodeKind, module.Name, module.Name)
+		for _, provides := range module.Provides {
+			g.addNode(ProvidesNodeKind, provides.Name, provides.Name)
+		}
+		for _, hook := range module.Hooks {
+			g.addNode(HookNodeKind, module.Name+graphHookModuleSeparator+hook.Name, hook.Name)
+		}
+	}
+	for _, resource := range mta.Resources {
+		g.addNode(ResourceNodeKind, resource.Name, resource.Name)
+	}
+
+	for _, module := range mta.Modules {
+		moduleID := getGraphNodeID(ModuleNodeKind, module.Name)
+		for _, provides := range module.Provides {
+			g.addEdge(moduleID, getGraphNodeID(ProvidesNodeKind, provides.Name), ProvidesEdgeKind)
+		}
+		g.addRequiresEdges(moduleID, module.Requires)
+		for _, name := range module.DeployedAfter {
+			if g.hasNode(getGraphNodeID(ModuleNodeKind, name)) {
+				g.addEdge(moduleID, getGraphNodeID(ModuleNodeKind, name), DeployedAfterEdgeKind)
+			}
+		}
+		for _, hook := range module.Hooks {
+			hookID := getGraphNodeID(HookNodeKind, module.Name+graphHookModuleSeparator+hook.Name)
+			g.addEdge(moduleID, hookID, HookEdgeKind)
+			g.addRequiresEdges(hookID, hook.Requires)
+		}
+	}
+	for _, resource := range mta.Resources {
+		g.addRequiresEdges(getGraphNodeID(ResourceNodeKind, resource.Name), resource.Requires)
+	}
+	return g
+}
+
+func getGraphNodeID(kind, name string) string {
+	return kind + graphNodeIDSeparator + name
+}
+
+func (g *Graph) addNode(kind, name, label string) {
+	id := getGraphNodeID(kind, name)
+	if !g.hasNode(id) {
+		g.Nodes = append(g.Nodes, GraphNode{ID: id, Name: label, Kind: kind})
+	}
+}
+
+func (g *Graph) hasNode(id string) bool {
+	return g.getNodeIndex(id) >= 0
+}
+
+func (g *Graph) getNodeIndex(id string) int {
+	for i, node := range g.Nodes {
+		if node.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *Graph) addEdge(from, to, kind string) {
+	edge := GraphEdge{From: from, To: to, Kind: kind}
+	for _, e := range g.Edges {
+		if e == edge {
+			return
+		}
+	}
+	g.Edges = append(g.Edges, edge)
+}
+
+// addRequiresEdges adds the edges from the node to the provided property sets and resources it requires.
+// A provided property set takes precedence over a resource with the same name.
+func (g *Graph) addRequiresEdges(from string, requires []Requires) {
+	for _, req := range requires {
+		if g.hasNode(getGraphNodeID(ProvidesNodeKind, req.Name)) {
+			g.addEdge(from, getGraphNodeID(ProvidesNodeKind, req.Name), RequiresEdgeKind)
+		} else if g.hasNode(getGraphNodeID(ResourceNodeKind, req.Name)) {
+			g.addEdge(from, getGraphNodeID(ResourceNodeKind, req.Name), RequiresEdgeKind)
+		}
+	}
+}
+
+// GetModuleClosure returns the part of the graph which the module depends on, directly or transitively:
+// the nodes reachable from the module, with the edges starting from them. A module which provides a reachable
+// provided property set is reachable as well, because the property set depends on it.
+func (g *Graph) GetModuleClosure(moduleName string) (*Graph, error) {
+	start := getGraphNodeID(ModuleNodeKind, moduleName)
+	if !g.hasNode(start) {
+		return nil, errors.Errorf(graphNodeNotFoundMsg, moduleName)
+	}
+
+	reached := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, edge := range g.Edges {
+			if edge.From == id && !reached[edge.To] {
+				reached[edge.To] = true
+				queue = append(queue, edge.To)
+			} else if edge.To == id && edge.Kind == ProvidesEdgeKind && !reached[edge.From] {
+				reached[edge.From] = true
+				queue = append(queue, edge.From)
+			}
+		}
+	}
+
+	closure := &Graph{}
+	for _, node := range g.Nodes {
+		if reached[node.ID] {
+			closure.Nodes = append(closure.Nodes, node)
+		}
+	}
+	for _, edge := range g.Edges {
+		if reached[edge.From] {
+			closure.Edges = append(closure.Edges, edge)
+		}
+	}
+	return closure, nil
+}
+
+// GetAdjacency returns the edges of the graph grouped by the node they start from.
+// Every node of the graph has an entry, even if no edge starts from it.
+func (g *Graph) GetAdjacency() map[string][]GraphEdge {
+	adjacency := make(map[string][]GraphEdge, len(g.Nodes))
+	for _, node := range g.Nodes {
+		adjacency[node.ID] = []GraphEdge{}
+	}
+	for _, edge := range g.Edges {
+		adjacency[edge.From] = append(adjacency[edge.From], edge)
+	}
+	return adjacency
+}
+
+// Format returns the graph in the format: Graphviz DOT, Mermaid flowchart or JSON adjacency
+func (g *Graph) Format(format string) (string, error) {
+	switch format {
+	case DOTGraphFormat:
+		return g.DOT(), nil
+	case MermaidGraphFormat:
+		return g.Mermaid(), nil
+	case JSONGraphFormat:
+		return g.JSON()
+	}
+	return "", errors.Errorf(unknownGraphFormatMsg, format,
+		strings.Join([]string{DOTGraphFormat, MermaidGraphFormat, JSONGraphFormat}, graphFormatsSeparator))
+}
+
+// DOT returns the graph in the Graphviz DOT format
+func (g *Graph) DOT() string {
+	shapes := map[string]string{
+		ModuleNodeKind:   "box",
+		ResourceNodeKind: "cylinder",
+		ProvidesNodeKind: "ellipse",
+		HookNodeKind:     "hexagon",
+	}
+	var buf bytes.Buffer
+	buf.WriteString("digraph mta {\n")
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&buf, "  %q [label=%q, shape=%s];\n", node.ID, node.Name, shapes[node.Kind])
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&buf, "  %q -> %q [label=%q];\n", edge.From, edge.To, edge.Kind)
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+// Mermaid returns the graph as a Mermaid flowchart
+func (g *Graph) Mermaid() string {
+	// Mermaid node IDs cannot contain all the characters allowed in MTA names, so the nodes are numbered
+	shapes := map[string][2]string{
+		ModuleNodeKind:   {"[", "]"},
+		ResourceNodeKind: {"[(", ")]"},
+		ProvidesNodeKind: {"([", "])"},
+		HookNodeKind:     {"{{", "}}"},
+	}
+	var buf bytes.Buffer
+	buf.WriteString("flowchart LR\n")
+	for i, node := range g.Nodes {
+		shape := shapes[node.Kind]
+		fmt.Fprintf(&buf, "  n%d%s\"%s\"%s\n", i, shape[0], strings.Replace(node.Name, `"`, "#quot;", -1), shape[1])
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&buf, "  n%d -->|%s| n%d\n", g.getNodeIndex(edge.From), edge.Kind, g.getNodeIndex(edge.To))
+	}
+	return buf.String()
+}
+
+// JSON returns the nodes of the graph and its adjacency in JSON format
+func (g *Graph) JSON() (string, error) {
+	nodes := g.Nodes
+	if nodes == nil {
+		nodes = []GraphNode{}
+	}
+	content, err := json.MarshalIndent(struct {
+		Nodes     []GraphNode            `json:"nodes"`
+		Adjacency map[string][]GraphEdge `json:"adjacency"`
+	}{nodes, g.GetAdjacency()}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(content) + "\n", nil
+}
+
+// GetGraph returns the dependency graph of the MTA in the path, or the part of it reachable from the module
+// if the module name is not empty, in the format: Graphviz DOT, Mermaid flowchart or JSON adjacency
+func GetGraph(path string, moduleName string, format string) (string, error) {
+	mta, err := getMtaFromFile(path)
+	if err != nil {
+		return "", err
+	}
+	g := mta.GetGraph()
+	if len(moduleName) > 0 {
+		g, err = g.GetModuleClosure(moduleName)
+		if err != nil {
+			return "", err
+		}
+	}
+	return g.Format(format)
+}
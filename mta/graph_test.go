@@ -0,0 +1,131 @@
+package mta
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Graph", func() {
+	var graph *Graph
+
+	BeforeEach(func() {
+		mta, err := getMtaFromFile(getTestPath("graph", "mta.yaml"))
+		Ω(err).Should(Succeed())
+		graph = mta.GetGraph()
+	})
+
+	It("builds the graph of the MTA", func() {
+		Ω(graph.Nodes).Should(Equal([]GraphNode{
+			{"module:ui", "ui", ModuleNodeKind},
+			{"module:backend", "backend", ModuleNodeKind},
+			{"provides:backend_api", "backend_api", ProvidesNodeKind},
+			{"hook:backend/migrate", "migrate", HookNodeKind},
+			{"module:jobs", "jobs", ModuleNodeKind},
+			{"resource:db", "db", ResourceNodeKind},
+			{"resource:config", "config", ResourceNodeKind},
+		}))
+		Ω(graph.Edges).Should(Equal([]GraphEdge{
+			{"module:ui", "provides:backend_api", RequiresEdgeKind},
+			{"module:ui", "module:backend", DeployedAfterEdgeKind},
+			{"module:backend", "provides:backend_api", ProvidesEdgeKind},
+			{"module:backend", "resource:db", RequiresEdgeKind},
+			{"module:backend", "hook:backend/migrate", HookEdgeKind},
+			{"hook:backend/migrate", "resource:db", RequiresEdgeKind},
+			{"module:jobs", "resource:config", RequiresEdgeKind},
+			{"resource:config", "provides:backend_api", RequiresEdgeKind},
+		}))
+	})
+
+	It("returns the transitive closure of a module", func() {
+		closure, err := graph.GetModuleClosure("jobs")
+		Ω(err).Should(Succeed())
+		Ω(closure.Nodes).Should(Equal([]GraphNode{
+			{"module:backend", "backend", ModuleNodeKind},
+			{"provides:backend_api", "backend_api", ProvidesNodeKind},
+			{"hook:backend/migrate", "migrate", HookNodeKind},
+			{"module:jobs", "jobs", ModuleNodeKind},
+			{"resource:db", "db", ResourceNodeKind},
+			{"resource:config", "config", ResourceNodeKind},
+		}))
+		Ω(closure.Edges).Should(Equal([]GraphEdge{
+			{"module:backend", "provides:backend_api", ProvidesEdgeKind},
+			{"module:backend", "resource:db", RequiresEdgeKind},
+			{"module:backend", "hook:backend/migrate", HookEdgeKind},
+			{"hook:backend/migrate", "resource:db", RequiresEdgeKind},
+			{"module:jobs", "resource:config", RequiresEdgeKind},
+			{"resource:config", "provides:backend_api", RequiresEdgeKind},
+		}))
+	})
+
+	It("returns the transitive closure of a module without dependencies", func() {
+		closure, err := graph.GetModuleClosure("backend")
+		Ω(err).Should(Succeed())
+		Ω(closure.Nodes).ShouldNot(ContainElement(GraphNode{"module:ui", "ui", ModuleNodeKind}))
+	})
+
+	It("fails to return the closure of an unknown module", func() {
+		_, err := graph.GetModuleClosure("unknown")
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(fmt.Sprintf(graphNodeNotFoundMsg, "unknown")))
+	})
+
+	It("formats the graph in DOT format", func() {
+		closure, _ := graph.GetModuleClosure("backend")
+		Ω(closure.Format(DOTGraphFormat)).Should(Equal(`digraph mta {
+  "module:backend" [label="backend", shape=box];
+  "provides:backend_api" [label="backend_api", shape=ellipse];
+  "hook:backend/migrate" [label="migrate", shape=hexagon];
+  "resource:db" [label="db", shape=cylinder];
+  "module:backend" -> "provides:backend_api" [label="provides"];
+  "module:backend" -> "resource:db" [label="requires"];
+  "module:backend" -> "hook:backend/migrate" [label="hook"];
+  "hook:backend/migrate" -> "resource:db" [label="requires"];
+}
+`))
+	})
+
+	It("formats the graph in Mermaid format", func() {
+		closure, _ := graph.GetModuleClosure("backend")
+		Ω(closure.Format(MermaidGraphFormat)).Should(Equal(`flowchart LR
+  n0["backend"]
+  n1(["backend_api"])
+  n2{{"migrate"}}
+  n3[("db")]
+  n0 -->|provides| n1
+  n0 -->|requires| n3
+  n0 -->|hook| n2
+  n2 -->|requires| n3
+`))
+	})
+
+	It("formats the graph in JSON format", func() {
+		content, err := graph.Format(JSONGraphFormat)
+		Ω(err).Should(Succeed())
+		var result struct {
+			Nodes     []GraphNode            `json:"nodes"`
+			Adjacency map[string][]GraphEdge `json:"adjacency"`
+		}
+		Ω(json.Unmarshal([]byte(content), &result)).Should(Succeed())
+		Ω(result.Nodes).Should(Equal(graph.Nodes))
+		Ω(result.Adjacency).Should(Equal(graph.GetAdjacency()))
+		Ω(result.Adjacency["resource:db"]).Should(BeEmpty())
+	})
+
+	It("fails on an unknown format", func() {
+		_, err := graph.Format("svg")
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("gets the graph of the MTA file", func() {
+		content, err := GetGraph(getTestPath("graph", "mta.yaml"), "jobs", MermaidGraphFormat)
+		Ω(err).Should(Succeed())
+		Ω(content).Should(ContainSubstring(`-->|requires|`))
+		_, err = GetGraph(getTestPath("graph", "notExists.yaml"), "", MermaidGraphFormat)
+		Ω(err).Should(HaveOccurred())
+		_, err = GetGraph(getTestPath("graph", "mta.yaml"), "unknown", MermaidGraphFormat)
+		Ω(err).Should(HaveOccurred())
+	})
+})
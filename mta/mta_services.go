@@ -38,14 +38,21 @@ func createMtaYamlFile(path string, mkDirs func(string, os.FileMode) error) (rer
 }
 
 func getMtaFromFile(path string) (*MTA, error) {
+	mtaContent, err := readMtaContent(path)
+	if err != nil {
+		return nil, err
+	}
+	return Unmarshal(mtaContent)
+}
+
+func readMtaContent(path string) ([]byte, error) {
 	mtaContent, err := ioutil.ReadFile(filepath.Join(path))
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed when reading the '%s' file", path)
 	}
 	s := string(mtaContent)
 	s = strings.Replace(s, "\r\n", "\r", -1)
-	mtaContent = []byte(s)
-	return Unmarshal(mtaContent)
+	return []byte(s), nil
 }
 
 func unmarshalData(dataJSON string, o interface{}) error {
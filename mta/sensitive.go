@@ -0,0 +1,55 @@
+package mta
+
+// RedactedValue replaces the values of the sensitive properties and parameters in the output
+const RedactedValue = "********"
+
+// Redact replaces the values of the properties and parameters marked as sensitive in their metadata
+// in the whole MTA: the MTA parameters, the modules and the resources
+func (mta *MTA) Redact() {
+	redactMap(mta.Parameters, mta.ParametersMetaData)
+	RedactModules(mta.Modules)
+	RedactResources(mta.Resources)
+}
+
+// RedactModules replaces the values of the properties and parameters marked as sensitive in their metadata
+// in the modules, with their provides, requires and hooks
+func RedactModules(modules []*Module) {
+	for _, module := range modules {
+		redactMap(module.Properties, module.PropertiesMetaData)
+		redactMap(module.Parameters, module.ParametersMetaData)
+		for _, provides := range module.Provides {
+			redactMap(provides.Properties, provides.PropertiesMetaData)
+		}
+		redactRequires(module.Requires)
+		for _, hook := range module.Hooks {
+			redactMap(hook.Parameters, hook.ParametersMetaData)
+			redactRequires(hook.Requires)
+		}
+	}
+}
+
+// RedactResources replaces the values of the properties and parameters marked as sensitive in their metadata
+// in the resources and their requires
+func RedactResources(resources []*Resource) {
+	for _, resource := range resources {
+		redactMap(resource.Properties, resource.PropertiesMetaData)
+		redactMap(resource.Parameters, resource.ParametersMetaData)
+		redactRequires(resource.Requires)
+	}
+}
+
+func redactRequires(requires []Requires) {
+	for _, req := range requires {
+		redactMap(req.Properties, req.PropertiesMetaData)
+		redactMap(req.Parameters, req.ParametersMetaData)
+	}
+}
+
+// redactMap replaces the sensitive values in the map; empty values are kept, so it is visible they are not set
+func redactMap(m map[string]interface{}, meta map[string]MetaData) {
+	for key, metaData := range meta {
+		if value, ok := m[key]; ok && value != nil && metaData.Sensitive {
+			m[key] = RedactedValue
+		}
+	}
+}
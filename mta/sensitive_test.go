@@ -0,0 +1,50 @@
+package mta
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redact", func() {
+	It("replaces the values of the sensitive properties and parameters", func() {
+		sensitive := map[string]MetaData{"secret": {Sensitive: true}, "empty": {Sensitive: true}, "plain": {}}
+		newValues := func() map[string]interface{} {
+			return map[string]interface{}{"secret": "s3cr3t", "empty": nil, "plain": "value"}
+		}
+		redacted := map[string]interface{}{"secret": RedactedValue, "empty": nil, "plain": "value"}
+
+		mta := &MTA{
+			Parameters:         newValues(),
+			ParametersMetaData: sensitive,
+			Modules: []*Module{{
+				Name:               "srv",
+				Properties:         newValues(),
+				PropertiesMetaData: sensitive,
+				Provides:           []Provides{{Name: "api", Properties: newValues(), PropertiesMetaData: sensitive}},
+				Requires:           []Requires{{Name: "db", Parameters: newValues(), ParametersMetaData: sensitive}},
+				Hooks: []Hook{{
+					Name:               "hook",
+					Parameters:         newValues(),
+					ParametersMetaData: sensitive,
+					Requires:           []Requires{{Name: "db", Properties: newValues(), PropertiesMetaData: sensitive}},
+				}},
+			}},
+			Resources: []*Resource{{
+				Name:               "db",
+				Parameters:         newValues(),
+				ParametersMetaData: sensitive,
+				Requires:           []Requires{{Name: "api", Properties: newValues(), PropertiesMetaData: sensitive}},
+			}},
+		}
+		mta.Redact()
+
+		Ω(mta.Parameters).Should(Equal(redacted))
+		Ω(mta.Modules[0].Properties).Should(Equal(redacted))
+		Ω(mta.Modules[0].Provides[0].Properties).Should(Equal(redacted))
+		Ω(mta.Modules[0].Requires[0].Parameters).Should(Equal(redacted))
+		Ω(mta.Modules[0].Hooks[0].Parameters).Should(Equal(redacted))
+		Ω(mta.Modules[0].Hooks[0].Requires[0].Properties).Should(Equal(redacted))
+		Ω(mta.Resources[0].Parameters).Should(Equal(redacted))
+		Ω(mta.Resources[0].Requires[0].Properties).Should(Equal(redacted))
+	})
+})
@@ -0,0 +1,218 @@
+package mta
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	moduleTypeCycleMsg         = `the "%s" module type extends itself: %s`
+	resourceTypeCycleMsg       = `the "%s" resource type extends itself: %s`
+	extendModuleTypeMsg        = `could not apply the "%s" module type to the "%s" module type`
+	extendResourceTypeMsg      = `could not apply the "%s" resource type to the "%s" resource type`
+	applyModuleTypePropsMsg    = `could not apply the properties of the "%s" module type to the "%s" module`
+	applyModuleTypeParamsMsg   = `could not apply the parameters of the "%s" module type to the "%s" module`
+	applyResourceTypePropsMsg  = `could not apply the properties of the "%s" resource type to the "%s" resource`
+	applyResourceTypeParamsMsg = `could not apply the parameters of the "%s" resource type to the "%s" resource`
+	typeCycleSeparator         = " -> "
+)
+
+// typeDeclaration is a module type or a resource type declared in the MTA, with the values
+// inherited from the declared types it extends
+type typeDeclaration struct {
+	name               string
+	extends            string
+	properties         map[string]interface{}
+	propertiesMetaData map[string]MetaData
+	parameters         map[string]interface{}
+	parametersMetaData map[string]MetaData
+}
+
+// ExpandTypes applies the module types and resource types declared in the MTA to its modules and resources.
+// A declared type inherits the properties and parameters of the declared type it extends; a type which extends
+// a type that is not declared in the MTA (such as a type supported by the deployer) is the start of the chain.
+// The properties and parameters of the type are added to every module or resource of the type. A module or
+// resource value takes precedence over the value of the type, unless the type's metadata marks it as not overwritable.
+// The type declarations are kept unchanged.
+func (mta *MTA) ExpandTypes() error {
+	moduleTypes := make(map[string]*typeDeclaration)
+	for _, t := range mta.ModuleTypes {
+		moduleTypes[t.Name] = &typeDeclaration{t.Name, t.Extends, t.Properties, t.PropertiesMetaData, t.Parameters, t.ParametersMetaData}
+	}
+	resourceTypes := make(map[string]*typeDeclaration)
+	for _, t := range mta.ResourceTypes {
+		resourceTypes[t.Name] = &typeDeclaration{t.Name, t.Extends, t.Properties, t.PropertiesMetaData, t.Parameters, t.ParametersMetaData}
+	}
+
+	for _, module := range mta.Modules {
+		t, err := getEffectiveType(moduleTypes, module.Type, moduleTypeCycleMsg, extendModuleTypeMsg)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			continue
+		}
+		module.Properties, module.PropertiesMetaData, err =
+			applyType(t.properties, t.propertiesMetaData, module.Properties, module.PropertiesMetaData, applyModuleTypePropsMsg, t.name, module.Name)
+		if err != nil {
+			return err
+		}
+		module.Parameters, module.ParametersMetaData, err =
+			applyType(t.parameters, t.parametersMetaData, module.Parameters, module.ParametersMetaData, applyModuleTypeParamsMsg, t.name, module.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, resource := range mta.Resources {
+		t, err := getEffectiveType(resourceTypes, resource.Type, resourceTypeCycleMsg, extendResourceTypeMsg)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			continue
+		}
+		resource.Properties, resource.PropertiesMetaData, err =
+			applyType(t.properties, t.propertiesMetaData, resource.Properties, resource.PropertiesMetaData, applyResourceTypePropsMsg, t.name, resource.Name)
+		if err != nil {
+			return err
+		}
+		resource.Parameters, resource.ParametersMetaData, err =
+			applyType(t.parameters, t.parametersMetaData, resource.Parameters, resource.ParametersMetaData, applyResourceTypeParamsMsg, t.name, resource.Name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getEffectiveType returns the declared type with the values inherited through its "extends" chain,
+// or nil if the type is not declared
+func getEffectiveType(types map[string]*typeDeclaration, name string, cycleMsg string, extendMsg string) (*typeDeclaration, error) {
+	t, ok := types[name]
+	if !ok {
+		return nil, nil
+	}
+
+	// The chain starts from the type itself and ends with the most basic declared type
+	chainTypes := []*typeDeclaration{t}
+	visited := map[string]bool{name: true}
+	for next, ok := types[t.extends]; ok; next, ok = types[next.extends] {
+		if visited[next.name] {
+			names := make([]string, 0, len(chainTypes)+1)
+			for _, c := range chainTypes {
+				names = append(names, c.name)
+			}
+			names = append(names, next.name)
+			return nil, errors.Errorf(cycleMsg, name, strings.Join(names, typeCycleSeparator))
+		}
+		visited[next.name] = true
+		chainTypes = append(chainTypes, next)
+	}
+
+	base := chainTypes[len(chainTypes)-1]
+	effective := &typeDeclaration{name: name}
+	effective.properties, effective.propertiesMetaData = copyMap(base.properties), copyMetaData(base.propertiesMetaData)
+	effective.parameters, effective.parametersMetaData = copyMap(base.parameters), copyMetaData(base.parametersMetaData)
+	for i := len(chainTypes) - 2; i >= 0; i-- {
+		derived := chainTypes[i]
+		err := chain().
+			extendMap(&effective.properties, effective.propertiesMetaData, copyMap(derived.properties), extendMsg, chainTypes[i+1].name, derived.name).
+			extendMap(&effective.parameters, effective.parametersMetaData, copyMap(derived.parameters), extendMsg, chainTypes[i+1].name, derived.name).
+			err
+		if err != nil {
+			return nil, err
+		}
+		effective.propertiesMetaData = extendMetaData(effective.propertiesMetaData, derived.propertiesMetaData)
+		effective.parametersMetaData = extendMetaData(effective.parametersMetaData, derived.parametersMetaData)
+	}
+	return effective, nil
+}
+
+// applyType returns the values of the type extended with the values of the module or resource,
+// and the metadata of the type extended with the metadata of the module or resource
+func applyType(typeValues map[string]interface{}, typeMeta map[string]MetaData, values map[string]interface{}, meta map[string]MetaData,
+	msg string, typeName string, name string) (map[string]interface{}, map[string]MetaData, error) {
+	if typeValues == nil {
+		return values, meta, nil
+	}
+	result := copyMap(typeValues)
+	if err := extendMap(&result, typeMeta, values); err != nil {
+		return nil, nil, errors.Wrapf(err, msg, typeName, name)
+	}
+	return result, extendMetaData(copyMetaData(typeMeta), meta), nil
+}
+
+// extendMetaData adds the metadata in ext to the metadata in meta, replacing the metadata of the same fields
+func extendMetaData(meta map[string]MetaData, ext map[string]MetaData) map[string]MetaData {
+	if len(ext) == 0 {
+		return meta
+	}
+	if meta == nil {
+		meta = make(map[string]MetaData, len(ext))
+	}
+	for key, value := range ext {
+		meta[key] = value
+	}
+	return meta
+}
+
+func copyMetaData(meta map[string]MetaData) map[string]MetaData {
+	if meta == nil {
+		return nil
+	}
+	result := make(map[string]MetaData, len(meta))
+	for key, value := range meta {
+		result[key] = value
+	}
+	return result
+}
+
+// copyMap returns a deep copy of the map, so that extending the copy does not change the nested maps of the original
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	result := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		result[key] = copyMapValue(value)
+	}
+	return result
+}
+
+func copyMapValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copyMap(v)
+	case map[interface{}]interface{}:
+		result := make(map[interface{}]interface{}, len(v))
+		for key, item := range v {
+			result[key] = copyMapValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = copyMapValue(item)
+		}
+		return result
+	}
+	return value
+}
+
+// GetExpandedMta returns the MTA in the path, merged with the MTA extension files, with its module types
+// and resource types applied to its modules and resources
+func GetExpandedMta(path string, extPaths []string) (*MTA, error) {
+	mta, err := getMtaFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err = MergeExtFiles(mta, extPaths); err != nil {
+		return nil, err
+	}
+	if err = mta.ExpandTypes(); err != nil {
+		return nil, err
+	}
+	return mta, nil
+}
@@ -0,0 +1,101 @@
+package mta
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExpandTypes", func() {
+	It("applies the module types and resource types with their extends chain", func() {
+		mta, err := getMtaFromFile(getTestPath("types", "mta.yaml"))
+		Ω(err).Should(Succeed())
+		Ω(mta.ExpandTypes()).Should(Succeed())
+
+		ui := mta.Modules[0]
+		Ω(ui.Type).Should(Equal("web-app"))
+		Ω(ui.Properties).Should(Equal(map[string]interface{}{
+			"LOG_LEVEL": "warn",
+			"logging":   map[string]interface{}{"format": "text", "level": "warn"},
+		}))
+		Ω(ui.Parameters).Should(Equal(map[string]interface{}{
+			"memory": "512M", "buildpack": "nodejs_buildpack", "disk-quota": "1G",
+		}))
+		Ω(ui.ParametersMetaData).Should(HaveKey("buildpack"))
+
+		srv := mta.Modules[1]
+		Ω(srv.Properties).Should(Equal(map[string]interface{}{
+			"LOG_LEVEL": "info",
+			"logging":   map[string]interface{}{"format": "json"},
+		}))
+		Ω(srv.Parameters).Should(Equal(map[string]interface{}{"memory": "1G", "buildpack": "nodejs_buildpack"}))
+
+		// The type is not declared in the MTA
+		Ω(mta.Modules[2].Properties).Should(BeNil())
+		Ω(mta.Modules[2].Parameters).Should(BeNil())
+
+		Ω(mta.Resources[0].Parameters).Should(Equal(map[string]interface{}{
+			"service": "hana", "service-plan": "hdi-shared-small",
+		}))
+	})
+
+	It("keeps the type declarations unchanged", func() {
+		mta, err := getMtaFromFile(getTestPath("types", "mta.yaml"))
+		Ω(err).Should(Succeed())
+		Ω(mta.ExpandTypes()).Should(Succeed())
+		Ω(mta.ModuleTypes[0].Properties).Should(Equal(map[string]interface{}{
+			"LOG_LEVEL": "info",
+			"logging":   map[string]interface{}{"format": "json"},
+		}))
+		Ω(mta.ModuleTypes[1].Parameters).Should(Equal(map[string]interface{}{"memory": "512M"}))
+	})
+
+	It("fails when a module overwrites a value which is not overwritable in its type", func() {
+		mta, err := getMtaFromFile(getTestPath("types", "mta.yaml"))
+		Ω(err).Should(Succeed())
+		mta.Modules[0].Parameters["buildpack"] = "custom_buildpack"
+		err = mta.ExpandTypes()
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(`could not apply the parameters of the "web-app" module type to the "ui" module`))
+		Ω(err.Error()).Should(ContainSubstring(`the "buildpack" field cannot be overwritten`))
+	})
+
+	It("fails when a type overwrites a value which is not overwritable in the type it extends", func() {
+		mta, err := getMtaFromFile(getTestPath("types", "mta.yaml"))
+		Ω(err).Should(Succeed())
+		mta.ModuleTypes[1].Parameters["buildpack"] = "custom_buildpack"
+		err = mta.ExpandTypes()
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(`could not apply the "base-app" module type to the "web-app" module type`))
+	})
+
+	It("fails on a cycle in the extends chain", func() {
+		mta, err := getMtaFromFile(getTestPath("types", "cycle.yaml"))
+		Ω(err).Should(Succeed())
+		err = mta.ExpandTypes()
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(`the "a" resource type extends itself: a -> b -> a`))
+	})
+})
+
+var _ = Describe("GetExpandedMta", func() {
+	It("applies the types after merging the extensions", func() {
+		mta, err := GetExpandedMta(getTestPath("types", "mta.yaml"), []string{getTestPath("types", "dev.mtaext")})
+		Ω(err).Should(Succeed())
+		Ω(mta.Modules[1].Parameters).Should(Equal(map[string]interface{}{"memory": "2G", "buildpack": "nodejs_buildpack"}))
+	})
+
+	It("fails when an extension overwrites a value which is not overwritable in the type", func() {
+		_, err := GetExpandedMta(getTestPath("types", "mta.yaml"), []string{getTestPath("types", "overwrite.mtaext")})
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("fails when the MTA file does not exist", func() {
+		_, err := GetExpandedMta(getTestPath("types", "missing.yaml"), nil)
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("fails when an extension file does not exist", func() {
+		_, err := GetExpandedMta(getTestPath("types", "mta.yaml"), []string{getTestPath("types", "missing.mtaext")})
+		Ω(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,203 @@
+package mta
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// The kinds of usages
+const (
+	// RequiresUsageKind is a requires entry of the provided property set or resource
+	RequiresUsageKind = "requires"
+	// VariableUsageKind is a ~{...} variable in a property or parameter value
+	VariableUsageKind = "variable"
+)
+
+const (
+	emptyUsageReferenceMsg = `provide the name of a resource or a provided property set, or a "<provider>/<property>" pair`
+	parseUsagesFileMsg     = `could not parse the "%s" file`
+
+	variablePrefix    = "~{"
+	variableSuffix    = "}"
+	variableSeparator = "/"
+
+	hookEntityKind     = "hook"
+	moduleEntityKind   = "module"
+	resourceEntityKind = "resource"
+)
+
+// Usage is a reference to a resource, a provided property set or a provided property
+type Usage struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	// Kind is the kind of the reference: a requires entry or a variable
+	Kind string `json:"kind"`
+	// EntityKind is the kind of the entity which holds the reference: a module, a hook or a resource
+	EntityKind string `json:"entityKind"`
+	// EntityName is the name of the entity which holds the reference; the name of a hook is "<module>/<hook>"
+	EntityName string `json:"entityName"`
+	// Variable is the variable as it is written in the value, for a variable reference
+	Variable string `json:"variable,omitempty"`
+}
+
+// usagesFinder finds the references to a provider (a resource or a provided property set),
+// or to a property of the provider if the property is not empty
+type usagesFinder struct {
+	provider string
+	property string
+	file     string
+	usages   []Usage
+}
+
+// GetUsages returns the references to a resource or a provided property set, or to a provided property if the
+// reference is a "<provider>/<property>" pair, in the MTA file in the path and in the MTA extension files.
+// The references are the requires entries of the resource or provided property set in modules, hooks and resources,
+// and the ~{...} variables in property and parameter values that refer to it. A variable in a requires entry
+// refers to the provider of the entry.
+// The usages are returned ordered by file and line.
+func GetUsages(path string, extPaths []string, reference string) ([]Usage, error) {
+	if len(reference) == 0 {
+		return nil, errors.New(emptyUsageReferenceMsg)
+	}
+	finder := usagesFinder{provider: reference}
+	if pos := strings.Index(reference, variableSeparator); pos >= 0 {
+		finder.provider = reference[:pos]
+		finder.property = reference[pos+1:]
+	}
+
+	for _, file := range append([]string{path}, extPaths...) {
+		content, err := readMtaContent(file)
+		if err != nil {
+			return nil, err
+		}
+		var root yaml.Node
+		if err = yaml.Unmarshal(content, &root); err != nil {
+			return nil, errors.Wrapf(err, parseUsagesFileMsg, file)
+		}
+		finder.file = file
+		finder.findInRoot(&root)
+	}
+
+	files := make(map[string]int)
+	for i, file := range append([]string{path}, extPaths...) {
+		files[file] = i
+	}
+	sort.SliceStable(finder.usages, func(i, j int) bool {
+		if finder.usages[i].File != finder.usages[j].File {
+			return files[finder.usages[i].File] < files[finder.usages[j].File]
+		}
+		return finder.usages[i].Line < finder.usages[j].Line
+	})
+	if finder.usages == nil {
+		return []Usage{}, nil
+	}
+	return finder.usages, nil
+}
+
+func (f *usagesFinder) findInRoot(root *yaml.Node) {
+	for _, moduleNode := range getSequenceContent(getMappingValue(root, "modules")) {
+		moduleName := getMappingScalar(moduleNode, "name")
+		f.findInEntity(moduleNode, moduleEntityKind, moduleName)
+		for _, providesNode := range getSequenceContent(getMappingValue(moduleNode, "provides")) {
+			f.findInValue(getMappingValue(providesNode, "properties"), "", moduleEntityKind, moduleName)
+		}
+		for _, hookNode := range getSequenceContent(getMappingValue(moduleNode, "hooks")) {
+			f.findInEntity(hookNode, hookEntityKind, moduleName+variableSeparator+getMappingScalar(hookNode, "name"))
+		}
+	}
+	for _, resourceNode := range getSequenceContent(getMappingValue(root, "resources")) {
+		f.findInEntity(resourceNode, resourceEntityKind, getMappingScalar(resourceNode, "name"))
+	}
+}
+
+// findInEntity finds the references in the properties, parameters and requires of a module, hook or resource
+func (f *usagesFinder) findInEntity(node *yaml.Node, entityKind, entityName string) {
+	f.findInValue(getMappingValue(node, "properties"), "", entityKind, entityName)
+	f.findInValue(getMappingValue(node, "parameters"), "", entityKind, entityName)
+	for _, requiresNode := range getSequenceContent(getMappingValue(node, "requires")) {
+		nameNode := getMappingValue(requiresNode, "name")
+		if nameNode == nil {
+			continue
+		}
+		if nameNode.Value == f.provider {
+			f.usages = append(f.usages, Usage{File: f.file, Line: nameNode.Line, Kind: RequiresUsageKind,
+				EntityKind: entityKind, EntityName: entityName})
+		}
+		f.findInValue(getMappingValue(requiresNode, "properties"), nameNode.Value, entityKind, entityName)
+		f.findInValue(getMappingValue(requiresNode, "parameters"), nameNode.Value, entityKind, entityName)
+	}
+}
+
+// findInValue finds the variables in the string values nested in the node.
+// Like in the resolver, all the variables in a requires entry refer to its provider, and outside of
+// a requires entry only the variables with a provider prefix are references.
+func (f *usagesFinder) findInValue(node *yaml.Node, requiresScope string, entityKind, entityName string) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		for _, variable := range getVariables(node.Value) {
+			provider, property := requiresScope, variable
+			if len(requiresScope) == 0 {
+				if pos := strings.Index(variable, variableSeparator); pos >= 0 {
+					provider, property = variable[:pos], variable[pos+1:]
+				}
+			}
+			if provider == f.provider && (len(f.property) == 0 || property == f.property) {
+				f.usages = append(f.usages, Usage{File: f.file, Line: node.Line, Kind: VariableUsageKind,
+					EntityKind: entityKind, EntityName: entityName, Variable: variablePrefix + variable + variableSuffix})
+			}
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			f.findInValue(node.Content[i], requiresScope, entityKind, entityName)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			f.findInValue(item, requiresScope, entityKind, entityName)
+		}
+	}
+}
+
+// getVariables returns the names of the ~{...} variables in the value.
+// A variable name which starts with "{" ends with "}}" and includes the first closing brace, like in the resolver.
+func getVariables(value string) []string {
+	var variables []string
+	for pos := 0; ; {
+		start := strings.Index(value[pos:], variablePrefix)
+		if start < 0 {
+			return variables
+		}
+		start += pos + len(variablePrefix)
+		suffix := variableSuffix
+		if strings.HasPrefix(value[start:], "{") {
+			suffix = variableSuffix + variableSuffix
+		}
+		end := strings.Index(value[start:], suffix)
+		if end < 0 {
+			return variables
+		}
+		end += start + len(suffix) - 1
+		variables = append(variables, value[start:end])
+		pos = end + 1
+	}
+}
+
+func getSequenceContent(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return node.Content
+}
+
+func getMappingScalar(node *yaml.Node, key string) string {
+	value := getMappingValue(node, key)
+	if value == nil {
+		return ""
+	}
+	return value.Value
+}
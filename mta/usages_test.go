@@ -0,0 +1,84 @@
+package mta
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetUsages", func() {
+	mtaPath := getTestPath("usages", "mta.yaml")
+	extPath := getTestPath("usages", "dev.mtaext")
+
+	It("returns the usages of a provided property set", func() {
+		usages, err := GetUsages(mtaPath, []string{extPath}, "backend_api")
+		Ω(err).Should(Succeed())
+		Ω(usages).Should(Equal([]Usage{
+			{mtaPath, 9, RequiresUsageKind, moduleEntityKind, "ui", ""},
+			{mtaPath, 11, VariableUsageKind, moduleEntityKind, "ui", "~{url}"},
+			{mtaPath, 12, VariableUsageKind, moduleEntityKind, "ui", "~{name}"},
+			{mtaPath, 14, VariableUsageKind, moduleEntityKind, "ui", "~{backend_api/url}"},
+			{mtaPath, 23, VariableUsageKind, moduleEntityKind, "backend", "~{backend_api/name}"},
+			{mtaPath, 30, RequiresUsageKind, hookEntityKind, "backend/migrate", ""},
+			{mtaPath, 33, VariableUsageKind, hookEntityKind, "backend/migrate", "~{url}"},
+			{mtaPath, 34, VariableUsageKind, hookEntityKind, "backend/migrate", "~{{url}}"},
+			{mtaPath, 43, RequiresUsageKind, resourceEntityKind, "config", ""},
+			{mtaPath, 45, VariableUsageKind, resourceEntityKind, "config", "~{url}"},
+			{extPath, 8, VariableUsageKind, moduleEntityKind, "ui", "~{backend_api/url}"},
+		}))
+	})
+
+	It("returns the usages of a provided property", func() {
+		usages, err := GetUsages(mtaPath, nil, "backend_api/name")
+		Ω(err).Should(Succeed())
+		Ω(usages).Should(Equal([]Usage{
+			{mtaPath, 9, RequiresUsageKind, moduleEntityKind, "ui", ""},
+			{mtaPath, 12, VariableUsageKind, moduleEntityKind, "ui", "~{name}"},
+			{mtaPath, 23, VariableUsageKind, moduleEntityKind, "backend", "~{backend_api/name}"},
+			{mtaPath, 30, RequiresUsageKind, hookEntityKind, "backend/migrate", ""},
+			{mtaPath, 43, RequiresUsageKind, resourceEntityKind, "config", ""},
+		}))
+	})
+
+	It("returns the usages of a resource", func() {
+		usages, err := GetUsages(mtaPath, nil, "db")
+		Ω(err).Should(Succeed())
+		Ω(usages).Should(Equal([]Usage{
+			{mtaPath, 25, RequiresUsageKind, moduleEntityKind, "backend", ""},
+		}))
+	})
+
+	It("returns an empty list when there are no usages", func() {
+		usages, err := GetUsages(mtaPath, nil, "unknown")
+		Ω(err).Should(Succeed())
+		Ω(usages).Should(BeEmpty())
+		Ω(usages).ShouldNot(BeNil())
+	})
+
+	It("fails on an empty reference", func() {
+		_, err := GetUsages(mtaPath, nil, "")
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("fails when a file does not exist", func() {
+		_, err := GetUsages(mtaPath, []string{getTestPath("usages", "notExists.mtaext")}, "db")
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("fails when a file cannot be parsed", func() {
+		_, err := GetUsages(mtaPath, []string{getTestPath("usages", "invalid.mtaext")}, "db")
+		Ω(err).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("getVariables", func() {
+	It("returns the variables in the value", func() {
+		Ω(getVariables("a ~{b} c ~{d/e}")).Should(Equal([]string{"b", "d/e"}))
+	})
+	It("returns a variable with double braces", func() {
+		Ω(getVariables("a ~{{var1}} ~{b}")).Should(Equal([]string{"{var1}", "b"}))
+	})
+	It("ignores an unterminated variable", func() {
+		Ω(getVariables("a ~{b")).Should(BeEmpty())
+		Ω(getVariables("a ~{")).Should(BeEmpty())
+	})
+})
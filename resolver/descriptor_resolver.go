@@ -0,0 +1,167 @@
+package resolver
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	marshalMtaFailsMsg = `could not marshal the resolved "%s" file`
+)
+
+// ResolveMTA - resolve the variables and placeholders in the whole MTA file and print the resolved MTA file to stdout.
+// The MTA extension files, if provided, are merged into the MTA before the resolution.
+// The environment files of the modules are read from the workspace directory.
+// The values of the sensitive properties and parameters are redacted, unless reveal is true.
+func ResolveMTA(workspaceDir, modulePath string, extensions []string, envFile string, env map[string]string, reveal bool) error {
+	m, envFileName, err := newMTAResolverFromFile(workspaceDir, modulePath, extensions, envFile, env)
+	if err != nil {
+		return err
+	}
+	resolved, resolveErr := m.ResolveMTA(envFileName)
+	m.logDiagnostics()
+	if !reveal {
+		resolved.Redact()
+		m.redactSensitiveValues()
+	}
+	content, err := mta.Marshal(resolved)
+	if err != nil {
+		return errors.Wrapf(err, marshalMtaFailsMsg, modulePath)
+	}
+	fmt.Print(string(content))
+	return resolveErr
+}
+
+// ResolveMTA resolves the variables and placeholders in all the sections of the MTA and returns the resolved MTA:
+// the MTA parameters, the properties, parameters, requires, provides and hooks of the modules,
+// and the parameters, properties and requires of the resources.
+// The environment file of a module is only used to resolve that module; the other sections are resolved
+// with the environment variables only.
+// An error is returned if some of the references cannot be resolved because they are cyclic or nested too deep;
+// the other references are resolved anyway.
+func (m *MTAResolver) ResolveMTA(envFileName string) (*mta.MTA, error) {
+	if m.Parameters == nil {
+		m.Parameters = map[string]interface{}{}
+	}
+	globalContext := m.context.global
+	unresolved := m.MTA.Copy()
+	resolved := unresolved.Copy()
+
+	for i := range unresolved.Modules {
+		// each module is resolved in a copy of the unresolved MTA with its own copy of the global context,
+		// which gets the module's environment file. The provided properties of the other modules are resolved
+		// in the context of the module which requires them, so the result does not depend on the order of the modules.
+		m.MTA = *unresolved.Copy()
+		m.context.global = copyContext(globalContext)
+		m.getGraph().resolved = map[string]interface{}{}
+		m.sensitiveRefs = map[string]bool{}
+
+		module := m.Modules[i]
+		// the errors are collected in the reference graph and returned at the end
+		_ = m.ResolveProperies(module, envFileName)
+		m.resolveModuleSections(module)
+		resolved.Modules[i] = module
+	}
+
+	m.MTA = *unresolved.Copy()
+	m.context.global = globalContext
+	m.getGraph().resolved = map[string]interface{}{}
+	m.sensitiveRefs = map[string]bool{}
+	m.addEnvironmentToContext()
+
+	for key, value := range m.Parameters {
+		m.Parameters[key] = m.resolvePlaceholders(nil, nil, nil, value)
+		m.markSensitive(m.Parameters, key)
+	}
+	for _, resource := range m.Resources {
+		m.resolveResource(resource)
+	}
+	resolved.Parameters = m.Parameters
+	resolved.Resources = m.Resources
+
+	m.MTA = *resolved
+	return &m.MTA, m.getGraph().err()
+}
+
+// resolveModuleSections resolves the sections of the module which are not needed to build its environment
+func (m *MTAResolver) resolveModuleSections(module *mta.Module) {
+	for key, value := range module.Parameters {
+		paramValue := m.resolve(module, nil, value)
+		module.Parameters[key] = m.resolvePlaceholders(module, nil, nil, paramValue)
+		m.markSensitive(module.Parameters, key)
+	}
+
+	for _, req := range module.Requires {
+		m.resolveRequiresParameters(module, &req)
+	}
+
+	for _, provides := range module.Provides {
+		source := m.findProvider(provides.Name)
+		for propName, propValue := range provides.Properties {
+			provides.Properties[propName] = m.resolveProvidedProperty(source, provides.Name, propName, propValue)
+			m.markSensitive(provides.Properties, propName)
+		}
+	}
+
+	for _, hook := range module.Hooks {
+		for key, value := range hook.Parameters {
+			paramValue := m.resolve(module, nil, value)
+			hook.Parameters[key] = m.resolvePlaceholders(module, nil, nil, paramValue)
+			m.markSensitive(hook.Parameters, key)
+		}
+		for _, req := range hook.Requires {
+			requiredSource := m.findProvider(req.Name)
+			for propName, propValue := range req.Properties {
+				resolvedValue := m.resolve(module, &req, propValue)
+				req.Properties[propName] = m.resolvePlaceholders(module, requiredSource, &req, resolvedValue)
+				m.markSensitive(req.Properties, propName)
+			}
+			m.resolveRequiresParameters(module, &req)
+		}
+	}
+}
+
+// resolveResource resolves the parameters, properties and requires of the resource.
+// The placeholders are resolved in the resource scope: resource -> MTA -> global.
+func (m *MTAResolver) resolveResource(resource *mta.Resource) {
+	source := m.findProvider(resource.Name)
+	for key, value := range resource.Parameters {
+		resource.Parameters[key] = m.resolvePlaceholders(nil, source, nil, value)
+		m.markSensitive(resource.Parameters, key)
+	}
+
+	for propName, propValue := range resource.Properties {
+		resource.Properties[propName] = m.resolveProvidedProperty(source, resource.Name, propName, propValue)
+		m.markSensitive(resource.Properties, propName)
+	}
+
+	for _, req := range resource.Requires {
+		requiredSource := m.findProvider(req.Name)
+		for propName, propValue := range req.Properties {
+			resolvedValue := m.resolve(nil, &req, propValue)
+			req.Properties[propName] = m.resolvePlaceholders(nil, requiredSource, &req, resolvedValue)
+			m.markSensitive(req.Properties, propName)
+		}
+		m.resolveRequiresParameters(nil, &req)
+	}
+}
+
+func (m *MTAResolver) resolveRequiresParameters(sourceModule *mta.Module, requires *mta.Requires) {
+	requiredSource := m.findProvider(requires.Name)
+	for key, value := range requires.Parameters {
+		paramValue := m.resolve(sourceModule, requires, value)
+		requires.Parameters[key] = m.resolvePlaceholders(sourceModule, requiredSource, requires, paramValue)
+		m.markSensitive(requires.Parameters, key)
+	}
+}
+
+func copyContext(context map[string]string) map[string]string {
+	res := make(map[string]string, len(context))
+	for key, value := range context {
+		res[key] = value
+	}
+	return res
+}
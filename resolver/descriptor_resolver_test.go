@@ -0,0 +1,134 @@
+package resolver
+
+import (
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("ResolveMTA", func() {
+	env := map[string]string{"space": "dev"}
+
+	It("resolves all the sections of the MTA", func() {
+		m, envFileName, err := newMTAResolverFromFile("", getTestPath("test-project", "mtaFull.yaml"), nil, "", env)
+		Ω(err).Should(Succeed())
+		resolved, err := m.ResolveMTA(envFileName)
+		Ω(err).Should(Succeed())
+
+		module := resolved.Modules[0]
+		Ω(module.Parameters["host"]).Should(Equal("srv-dev"))
+		Ω(module.Parameters["url"]).Should(Equal("https://srv-dev.example.com"))
+		Ω(module.Properties["db-name"]).Should(Equal("db-dev"))
+		Ω(module.Requires[0].Parameters["service-key"]).Should(Equal("db-dev-key"))
+		Ω(module.Provides[0].Properties["url"]).Should(Equal("https://srv-dev.example.com"))
+		Ω(module.Hooks[0].Parameters["name"]).Should(Equal("srv-dev-hook"))
+		Ω(module.Hooks[0].Parameters["command"]).Should(Equal("migrate --url https://srv-dev.example.com"))
+		Ω(module.Hooks[0].Requires[0].Properties["api-url"]).Should(Equal("https://srv-dev.example.com"))
+
+		Ω(resolved.Resources[0].Parameters["service-name"]).Should(Equal("db-dev"))
+		Ω(resolved.Resources[0].Properties["name"]).Should(Equal("db-dev"))
+		Ω(resolved.Resources[1].Requires[0].Properties["srv-url"]).Should(Equal("https://srv-dev.example.com"))
+	})
+
+	It("does not change the MTA it resolves", func() {
+		content, err := ioutil.ReadFile(getTestPath("test-project", "mtaFull.yaml"))
+		Ω(err).Should(Succeed())
+		original, err := mta.Unmarshal(content)
+		Ω(err).Should(Succeed())
+		expected, err := mta.Unmarshal(content)
+		Ω(err).Should(Succeed())
+
+		resolved, err := NewMTAResolver(original, env, nil).ResolveMTA("")
+		Ω(err).Should(Succeed())
+		Ω(resolved.Modules[0].Parameters["host"]).Should(Equal("srv-dev"))
+		Ω(original).Should(Equal(expected))
+	})
+
+	It("does not depend on the order of the modules with environment files", func() {
+		newModules := func() []*mta.Module {
+			return []*mta.Module{
+				{
+					Name: "a",
+					Path: "a",
+					Provides: []mta.Provides{
+						{Name: "a_api", Properties: map[string]interface{}{"url": "https://${host}"}},
+					},
+				},
+				{
+					Name: "b",
+					Path: "b",
+					Requires: []mta.Requires{
+						{Name: "a_api", Properties: map[string]interface{}{"a-url": "~{url}"}},
+					},
+				},
+			}
+		}
+		fs := MapFileSystem{
+			"a/.env": []byte("host=a-host"),
+			"b/.env": []byte("host=b-host"),
+		}
+		for _, reversed := range []bool{false, true} {
+			modules := newModules()
+			if reversed {
+				modules[0], modules[1] = modules[1], modules[0]
+			}
+			resolved, err := NewMTAResolver(&mta.MTA{Modules: modules}, env, fs).ResolveMTA(defaultEnvFileName)
+			Ω(err).Should(Succeed())
+			a, err := resolved.GetModuleByName("a")
+			Ω(err).Should(Succeed())
+			b, err := resolved.GetModuleByName("b")
+			Ω(err).Should(Succeed())
+			Ω(a.Provides[0].Properties["url"]).Should(Equal("https://a-host"))
+			Ω(b.Requires[0].Properties["a-url"]).Should(Equal("https://b-host"))
+		}
+	})
+
+	It("resolves the resources with the environment variables", func() {
+		m := NewMTAResolver(&mta.MTA{
+			Modules: []*mta.Module{
+				{
+					Name: "module1",
+					Properties: map[string]interface{}{
+						"prop1": "${space}",
+					},
+				},
+			},
+			Resources: []*mta.Resource{
+				{
+					Name: "resource1",
+					Parameters: map[string]interface{}{
+						"service-name": "service-${space}",
+					},
+				},
+			},
+		}, env, nil)
+		resolved, err := m.ResolveMTA("")
+		Ω(err).Should(Succeed())
+		Ω(resolved.Modules[0].Properties["prop1"]).Should(Equal("dev"))
+		Ω(resolved.Resources[0].Parameters["service-name"]).Should(Equal("service-dev"))
+	})
+
+	It("reports cyclic references in resource parameters", func() {
+		m := NewMTAResolver(&mta.MTA{
+			Resources: []*mta.Resource{
+				{
+					Name: "resource1",
+					Parameters: map[string]interface{}{
+						"a": "${b}",
+						"b": "${a}",
+					},
+				},
+			},
+		}, env, nil)
+		_, err := m.ResolveMTA("")
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring("${resource1/a} -> ${resource1/b}"))
+	})
+
+	It("fails when the MTA file is not found", func() {
+		Ω(ResolveMTA("", getTestPath("test-project", "mtaNotExists.yaml"), nil, "", env, false)).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,54 @@
+package resolver
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FileSystem provides the files read during the resolution, such as the environment files of the modules.
+// The file names are slash-separated paths, relative to the workspace directory.
+type FileSystem interface {
+	ReadFile(name string) ([]byte, error)
+}
+
+// NewDirFileSystem returns a FileSystem which reads the files from the workspace directory on the disk
+func NewDirFileSystem(dir string) FileSystem {
+	return dirFileSystem(dir)
+}
+
+type dirFileSystem string
+
+// ReadFile reads the file from the workspace directory
+func (dir dirFileSystem) ReadFile(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(string(dir), filepath.FromSlash(name)))
+}
+
+// MapFileSystem is a FileSystem which holds the contents of the files in memory, by their path
+type MapFileSystem map[string][]byte
+
+// ReadFile returns the content of the file, or an error if the file does not exist
+func (fs MapFileSystem) ReadFile(name string) ([]byte, error) {
+	content, ok := fs[path.Clean(name)]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return content, nil
+}
+
+// EnvironmentFromList builds the environment used for the resolution from a list of "key=value" strings,
+// such as the list returned by os.Environ
+func EnvironmentFromList(list []string) map[string]string {
+	env := map[string]string{}
+	for _, val := range list {
+		pos := strings.Index(val, "=")
+		if pos > 0 {
+			key := strings.Trim(val[:pos], " ")
+			value := strings.Trim(val[pos+1:], " ")
+			env[key] = value
+		}
+	}
+	return env
+}
@@ -0,0 +1,53 @@
+package resolver
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("FileSystem", func() {
+	It("reads the module's environment file from the file system", func() {
+		fs := MapFileSystem{
+			"srv/.env": []byte("env_var1=fromFile"),
+		}
+		m := NewMTAResolver(&mta.MTA{
+			Modules: []*mta.Module{
+				{
+					Name: "module1",
+					Path: "srv",
+					Properties: map[string]interface{}{
+						"prop1": "${env_var1}",
+						"prop2": "${env_var2}",
+					},
+				},
+			},
+		}, map[string]string{"env_var2": "fromEnv"}, fs)
+		env, err := m.ResolveModuleEnv("module1", defaultEnvFileName)
+		Ω(err).Should(Succeed())
+		Ω(env).Should(Equal(map[string]string{"prop1": "fromFile", "prop2": "fromEnv"}))
+	})
+	It("fails to read a file which does not exist", func() {
+		_, err := MapFileSystem{}.ReadFile("srv/.env")
+		Ω(os.IsNotExist(err)).Should(BeTrue())
+	})
+	It("reads a file from the workspace directory", func() {
+		content, err := NewDirFileSystem(getTestPath("test-project")).ReadFile("srv/.env")
+		Ω(err).Should(Succeed())
+		Ω(string(content)).Should(ContainSubstring("env_var1"))
+	})
+	It("builds the environment from a list", func() {
+		env := EnvironmentFromList([]string{"a=1", " b = 2 ", "c", "d=x=y"})
+		Ω(env).Should(Equal(map[string]string{"a": "1", "b": "2", "d": "x=y"}))
+	})
+})
+
+var _ = Describe("ResolveModuleEnv", func() {
+	It("fails when the module does not exist", func() {
+		_, err := NewMTAResolver(&mta.MTA{}, nil, nil).ResolveModuleEnv("module1", defaultEnvFileName)
+		Ω(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,798 @@
+// Package resolver resolves the variables (~{...}) and placeholders (${...}) of an MTA.
+// The resolution is based only on the MTA, the environment and the files provided to the resolver,
+// so the same input always gives the same result.
+package resolver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta/internal/logs"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	emptyModuleNameMsg = "provide a name for the module"
+	pathNotFoundMsg    = `could not find the "%s" path`
+	unmarshalFailsMsg  = `could not unmarshal the "%s"`
+	mergeExtFailsMsg   = `could not apply the MTA extensions to the "%s"`
+	moduleNotFoundMsg  = `could not find the "%s" module`
+	marshalFailsMag    = `could not marshal the "%s" environment variable`
+	missingPrefixMsg   = `could not resolve the value for the "~{%s}" variable; missing required prefix`
+	missingConfigMsg   = `could not resolve the value for the "~{%s}" variable; the "%s" resource gets it from the "%s" configuration provider`
+	missingParamMsg    = `could not resolve the value for the "${%s}" placeholder; the "%s" parameter is not defined`
+
+	arrayCollidesWithPropMsg  = `could not create the "%s" %s for the "%s" required property set; a property with the same name is already defined`
+	propCollidesWithArrayMsg  = `could not set the "%s" property of the "%s" required property set; a %s with the same name is already defined`
+	arrayCollidesWithArrayMsg = `could not create the "%s" %s for the "%s" required property set; a %s with the same name is already defined`
+
+	embeddedValueTypeMsg = `the "%s" reference is embedded in the "%s" string, but its value is a %s; the value is embedded in JSON format`
+
+	groupKind = "group"
+	listKind  = "list"
+	mapKind   = "map"
+
+	defaultEnvFileName = ".env"
+)
+
+// Resolve - resolve module's parameters and print the module's environment to stdout.
+// The MTA extension files, if provided, are merged into the MTA before the resolution.
+// The environment files of the modules are read from the workspace directory.
+// The values of the sensitive properties are redacted, unless reveal is true.
+func Resolve(workspaceDir, moduleName, modulePath string, extensions []string, envFile string, env map[string]string, reveal bool) error {
+	if len(moduleName) == 0 {
+		return errors.New(emptyModuleNameMsg)
+	}
+	m, envFileName, err := newMTAResolverFromFile(workspaceDir, modulePath, extensions, envFile, env)
+	if err != nil {
+		return err
+	}
+
+	propVarMap, err := m.resolveModuleEnv(moduleName, envFileName, reveal)
+	m.logDiagnostics()
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(propVarMap))
+	for key := range propVarMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Println(key + "=" + propVarMap[key])
+	}
+	return nil
+}
+
+// newMTAResolverFromFile reads the MTA file, merges the MTA extension files into it and creates its resolver.
+// It also returns the name of the environment file to use.
+func newMTAResolverFromFile(workspaceDir, modulePath string, extensions []string, envFile string, env map[string]string) (*MTAResolver, string, error) {
+	yamlData, err := ioutil.ReadFile(modulePath)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, pathNotFoundMsg, modulePath)
+	}
+	mtaRaw, err := mta.Unmarshal(yamlData)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, unmarshalFailsMsg, modulePath)
+	}
+	err = mta.MergeExtFiles(mtaRaw, extensions)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, mergeExtFailsMsg, modulePath)
+	}
+
+	if len(workspaceDir) == 0 {
+		workspaceDir = path.Dir(modulePath)
+	}
+
+	// If environment file name is not provided - set the default file name to .env
+	envFileName := defaultEnvFileName
+	if len(envFile) > 0 {
+		envFileName = envFile
+	}
+
+	return NewMTAResolver(mtaRaw, env, NewDirFileSystem(workspaceDir)), envFileName, nil
+}
+
+// getPropertiesAsEnvVar builds the module's environment from its properties and the properties of its requires.
+// The properties of a requires with a "group" or a "list" are not added to the top level of the environment;
+// each such requires contributes one element, holding its properties, to a JSON array named by the group or list.
+// The array elements keep the order of the requires in the module. If both "list" and "group" are defined,
+// "list" is used.
+func getPropertiesAsEnvVar(module *mta.Module) (map[string]string, error) {
+	envVar := map[string]interface{}{}
+	for key, val := range module.Properties {
+		envVar[key] = val
+	}
+
+	// arrayKinds holds the names of the arrays created for groups and lists, and the kind that created them
+	arrayKinds := map[string]string{}
+	for _, requires := range module.Requires {
+		arrayName, arrayKind := getRequiresArrayName(&requires)
+		if len(arrayName) == 0 {
+			for key, val := range requires.Properties {
+				if kind, ok := arrayKinds[key]; ok {
+					return nil, errors.Errorf(propCollidesWithArrayMsg, key, requires.Name, kind)
+				}
+				envVar[key] = val
+			}
+			continue
+		}
+
+		if kind, ok := arrayKinds[arrayName]; ok {
+			if kind != arrayKind {
+				return nil, errors.Errorf(arrayCollidesWithArrayMsg, arrayName, arrayKind, requires.Name, kind)
+			}
+		} else if _, ok := envVar[arrayName]; ok {
+			return nil, errors.Errorf(arrayCollidesWithPropMsg, arrayName, arrayKind, requires.Name)
+		} else {
+			arrayKinds[arrayName] = arrayKind
+			envVar[arrayName] = []interface{}{}
+		}
+
+		propMap := map[string]interface{}{}
+		for key, val := range requires.Properties {
+			propMap[key] = val
+		}
+		envVar[arrayName] = append(envVar[arrayName].([]interface{}), propMap)
+	}
+
+	//serialize
+	return serializePropertiesAsEnvVars(envVar)
+}
+
+// getRequiresArrayName returns the name and the kind of the array to which the requires properties are added,
+// or an empty name if the properties are added to the top level of the environment
+func getRequiresArrayName(requires *mta.Requires) (string, string) {
+	if len(requires.List) > 0 {
+		return requires.List, listKind
+	}
+	if len(requires.Group) > 0 {
+		return requires.Group, groupKind
+	}
+	return "", ""
+}
+
+func serializePropertiesAsEnvVars(envVar map[string]interface{}) (map[string]string, error) {
+	retEnvVar := map[string]string{}
+	for key, val := range envVar {
+		switch v := val.(type) {
+		case string:
+			retEnvVar[key] = v
+		default:
+			bytesVal, err := json.Marshal(val)
+			if err != nil {
+				return nil, errors.Errorf(marshalFailsMag, key)
+			}
+			retEnvVar[key] = string(bytesVal)
+		}
+	}
+
+	return retEnvVar, nil
+}
+
+// MTAResolver is used to resolve MTA properties' variables
+type MTAResolver struct {
+	mta.MTA
+	env         map[string]string
+	fs          FileSystem
+	context     *ResolveContext
+	graph       *referenceGraph
+	diagnostics []string
+	// sensitive is set when the value being resolved gets a value from a sensitive property or parameter
+	sensitive bool
+	// sensitiveRefs holds the provided properties whose resolved values are sensitive
+	sensitiveRefs map[string]bool
+	// sensitiveValues holds the resolved values which got a value from a sensitive property or parameter
+	sensitiveValues []sensitiveValue
+}
+
+// sensitiveValue - the key of a resolved value in its map
+type sensitiveValue struct {
+	values map[string]interface{}
+	key    string
+}
+
+const resourceType = 1
+const moduleType = 2
+
+const variablePrefix = "~"
+const placeholderPrefix = "$"
+
+type mtaSource struct {
+	Name               string
+	Parameters         map[string]interface{} `yaml:"parameters,omitempty"`
+	Properties         map[string]interface{} `yaml:"properties,omitempty"`
+	ParametersMetaData map[string]mta.MetaData
+	PropertiesMetaData map[string]mta.MetaData
+	Type               int
+	Module             *mta.Module
+	Resource           *mta.Resource
+}
+
+// NewMTAResolver is a factory function for MTAResolver.
+// The environment holds the environment variables available to the resolution; a variable named
+// "<module or resource name>/<name>" is only available in the scope of that module or resource.
+// The file system provides the environment files of the modules; if it is nil, the environment files are not read.
+// The resolver resolves a copy of the MTA, so the MTA is not changed.
+func NewMTAResolver(m *mta.MTA, env map[string]string, fs FileSystem) *MTAResolver {
+	resolver := &MTAResolver{*m.Copy(), env, fs, &ResolveContext{
+		global:    map[string]string{},
+		modules:   map[string]map[string]string{},
+		resources: map[string]map[string]string{},
+	}, newReferenceGraph(), nil, false, map[string]bool{}, nil}
+
+	for _, module := range m.Modules {
+		resolver.context.modules[module.Name] = map[string]string{}
+	}
+
+	for _, resource := range m.Resources {
+		resolver.context.resources[resource.Name] = map[string]string{}
+	}
+	return resolver
+}
+
+// ResolveProperies is the main function to trigger the resolution.
+// An error is returned if some of the references cannot be resolved because they are cyclic or nested too deep;
+// the other references are resolved anyway.
+func (m *MTAResolver) ResolveProperies(module *mta.Module, envFileName string) error {
+
+	if m.Parameters == nil {
+		m.Parameters = map[string]interface{}{}
+	}
+
+	m.addEnvironmentToContext()
+
+	//add .env file in module's path to the module context
+	if len(module.Path) > 0 && m.fs != nil {
+		content, err := m.fs.ReadFile(path.Join(module.Path, envFileName))
+		if err == nil {
+			envMap, err := godotenv.Parse(bytes.NewReader(content))
+			if err == nil {
+				for key, value := range envMap {
+					m.addValueToContext(key, value)
+				}
+			}
+		}
+	}
+	m.addServiceNames(module)
+
+	//top level properties
+	for key, value := range module.Properties {
+		//no expected variables
+		propValue := m.resolve(module, nil, value)
+		module.Properties[key] = m.resolvePlaceholders(module, nil, nil, propValue)
+		m.markSensitive(module.Properties, key)
+	}
+
+	//required properties:
+	for _, req := range module.Requires {
+		requiredSource := m.findProvider(req.Name)
+		for propName, PropValue := range req.Properties {
+			resolvedValue := m.resolve(module, &req, PropValue)
+			//replace value with resolved value
+			req.Properties[propName] = m.resolvePlaceholders(module, requiredSource, &req, resolvedValue)
+			m.markSensitive(req.Properties, propName)
+		}
+	}
+	return m.getGraph().err()
+}
+
+// ResolveModuleEnv resolves the module and returns its environment: its properties and the properties
+// of its requires, serialized as environment variables
+func (m *MTAResolver) ResolveModuleEnv(moduleName, envFileName string) (map[string]string, error) {
+	return m.resolveModuleEnv(moduleName, envFileName, true)
+}
+
+// resolveModuleEnv resolves the module and returns its environment, with the values of the sensitive
+// properties redacted unless reveal is true
+func (m *MTAResolver) resolveModuleEnv(moduleName, envFileName string, reveal bool) (map[string]string, error) {
+	for _, module := range m.GetModules() {
+		if module.Name == moduleName {
+			err := m.ResolveProperies(module, envFileName)
+			if err != nil {
+				return nil, err
+			}
+			if !reveal {
+				mta.RedactModules([]*mta.Module{module})
+				m.redactSensitiveValues()
+			}
+			return getPropertiesAsEnvVar(module)
+		}
+	}
+	return nil, errors.Errorf(moduleNotFoundMsg, moduleName)
+}
+
+// addEnvironmentToContext adds the environment variables to the context
+func (m *MTAResolver) addEnvironmentToContext() {
+	for key, value := range m.env {
+		m.addValueToContext(key, value)
+	}
+}
+
+func (m *MTAResolver) getGraph() *referenceGraph {
+	if m.graph == nil {
+		m.graph = newReferenceGraph()
+	}
+	return m.graph
+}
+
+func (m *MTAResolver) addValueToContext(key, value string) {
+	//if the key has format of "module/key", or "resource/key" writes the value to the module's context
+	slashPos := strings.Index(key, "/")
+	if slashPos > 0 {
+		modName := key[:slashPos]
+		key = key[slashPos+1:]
+		modulesContext, ok := m.context.modules[modName]
+		if !ok {
+			modulesContext, ok = m.context.resources[modName]
+		}
+		if ok {
+			modulesContext[key] = value
+		}
+	} else {
+		m.context.global[key] = value
+	}
+
+}
+
+func (m *MTAResolver) resolve(sourceModule *mta.Module, requires *mta.Requires, valueObj interface{}) interface{} {
+	switch valueObj.(type) {
+	case map[interface{}]interface{}:
+		v := convertToJSONSafe(valueObj)
+		return m.resolve(sourceModule, requires, v)
+	case map[string]interface{}:
+		value := valueObj.(map[string]interface{})
+		for k, v := range value {
+			value[k] = m.resolve(sourceModule, requires, v)
+		}
+		return value
+	case []interface{}:
+		value := valueObj.([]interface{})
+		for i, v := range value {
+			value[i] = m.resolve(sourceModule, requires, v)
+		}
+		return value
+	case string:
+		return m.resolveString(sourceModule, requires, valueObj.(string))
+	default:
+		//if the value is not a string but a leaf, just return it
+		return valueObj
+	}
+
+}
+
+func (m *MTAResolver) resolveString(sourceModule *mta.Module, requires *mta.Requires, value string) interface{} {
+	pos := 0
+
+	pos, variableName, wholeValue := parseNextVariable(pos, value, variablePrefix)
+	if pos < 0 {
+		//no variables
+		return value
+	}
+	varValue := m.getVariableValue(sourceModule, requires, variableName)
+
+	if wholeValue {
+		return varValue
+	}
+	for pos >= 0 {
+		varValueStr := m.embedValue(variablePrefix, variableName, value, varValue)
+		value = value[:pos] + varValueStr + value[pos+len(variableName)+3:]
+
+		pos, variableName, _ = parseNextVariable(pos+len(varValueStr), value, variablePrefix)
+		if pos >= 0 {
+			varValue = m.getVariableValue(sourceModule, requires, variableName)
+		}
+	}
+
+	return value
+}
+
+// embedValue returns the string that replaces the reference embedded in a string value.
+// Strings are embedded as is and other values are embedded in JSON format;
+// embedding a map or a list is reported as a diagnostic, because the result is rarely the intended value.
+func (m *MTAResolver) embedValue(prefix, name, value string, refValue interface{}) string {
+	if kind := getStructuredKind(refValue); len(kind) > 0 {
+		m.addDiagnostic(embeddedValueTypeMsg, prefix+"{"+name+"}", value, kind)
+	}
+	str, _ := convertToString(refValue)
+	return str
+}
+
+// getStructuredKind returns the kind of the value if it is a map or a list, or an empty string otherwise
+func getStructuredKind(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}, map[interface{}]interface{}:
+		return mapKind
+	case []interface{}:
+		return listKind
+	}
+	return ""
+}
+
+func convertToString(valueObj interface{}) (string, bool) {
+	switch v := valueObj.(type) {
+	case string:
+		return v, false
+	}
+	valueBytes, err := json.Marshal(convertToJSONSafe(valueObj))
+	if err != nil {
+		logs.Logger.Error(err)
+		return "", false
+	}
+	return string(valueBytes), true
+}
+
+// return start position, name of variable and if it is a whole value
+func parseNextVariable(pos int, value string, prefix string) (int, string, bool) {
+
+	endSign := "}"
+	posStart := strings.Index(value[pos:], prefix+"{")
+	if posStart < 0 {
+		return -1, "", false
+	}
+	posStart += pos
+
+	if posStart+2 < len(value) && string(value[posStart+2]) == "{" {
+		endSign = "}}"
+	}
+
+	posEnd := strings.Index(value[posStart+2:], endSign)
+	if posEnd < 0 {
+		//bad value
+		return -1, "", false
+	}
+	posEnd += posStart + 1 + len(endSign)
+	wholeValue := posStart == 0 && posEnd == len(value)-1
+
+	return posStart, value[posStart+2 : posEnd], wholeValue
+}
+
+// GetVariables returns the names of the ~{...} variables in the value, parsed by the same rules as in the resolution.
+// The name of a variable written with double braces, like ~{{name}}, keeps the inner braces.
+func GetVariables(value string) []string {
+	return getReferenceNames(value, variablePrefix)
+}
+
+// GetPlaceholders returns the names of the ${...} placeholders in the value, parsed by the same rules as in the resolution
+func GetPlaceholders(value string) []string {
+	return getReferenceNames(value, placeholderPrefix)
+}
+
+func getReferenceNames(value string, prefix string) []string {
+	var names []string
+	pos, name, _ := parseNextVariable(0, value, prefix)
+	for pos >= 0 {
+		names = append(names, name)
+		pos, name, _ = parseNextVariable(pos+len(name)+3, value, prefix)
+	}
+	return names
+}
+
+func (m *MTAResolver) getVariableValue(sourceModule *mta.Module, requires *mta.Requires, variableName string) interface{} {
+	var providerName string
+	if requires == nil {
+		slashPos := strings.Index(variableName, "/")
+		if slashPos > 0 {
+			providerName = variableName[:slashPos]
+			variableName = variableName[slashPos+1:]
+		} else {
+			logs.Logger.Warnf(missingPrefixMsg, variableName)
+			return "~{" + variableName + "}"
+		}
+
+	} else {
+		providerName = requires.Name
+	}
+
+	source := m.findProvider(providerName)
+	if source != nil {
+		for propName, propValue := range source.Properties {
+			if propName == variableName {
+				return m.resolveProvidedProperty(source, providerName, propName, propValue)
+			}
+		}
+	}
+
+	if source != nil && source.Type == resourceType && source.Resource.Type == "configuration" {
+		if provID, ok := source.Resource.Parameters["provider-id"].(string); ok {
+			m.addDiagnostic(missingConfigMsg, variableName, providerName, provID)
+		}
+	}
+
+	return "~{" + variableName + "}"
+}
+
+// resolveProvidedProperty resolves the variables and placeholders nested in the value of a provided property.
+// The resolved value does not depend on the scope of the requiring module, so it is resolved only once.
+// The resolved value is sensitive if the property is sensitive or gets a value from a sensitive property or parameter.
+func (m *MTAResolver) resolveProvidedProperty(source *mtaSource, providerName, propName string, propValue interface{}) interface{} {
+	ref := variablePrefix + "{" + providerName + "/" + propName + "}"
+	graph := m.getGraph()
+	if value, ok := graph.resolved[ref]; ok {
+		m.sensitive = m.sensitive || m.sensitiveRefs[ref]
+		return value
+	}
+	if !graph.enter(ref) {
+		return ref
+	}
+	defer graph.leave(ref)
+
+	// the sensitivity of the provided property does not depend on the value which references it
+	outerSensitive := m.sensitive
+	m.sensitive = source.PropertiesMetaData[propName].Sensitive
+
+	//Do not pass module and requires, because it is a wrong scope
+	//it is either global->module->requires
+	//or           global->resource
+	propValue = m.resolve(nil, nil, propValue)
+	propValue = m.resolvePlaceholders(nil, source, nil, propValue)
+	value := convertToJSONSafe(propValue)
+	graph.resolved[ref] = value
+	m.sensitiveRefs[ref] = m.sensitive
+	m.sensitive = outerSensitive || m.sensitive
+	return value
+}
+
+func (m *MTAResolver) resolvePlaceholders(sourceModule *mta.Module, source *mtaSource, requires *mta.Requires, valueObj interface{}) interface{} {
+	switch valueObj.(type) {
+	case map[interface{}]interface{}:
+		v := convertToJSONSafe(valueObj)
+		return m.resolvePlaceholders(sourceModule, source, requires, v)
+	case map[string]interface{}:
+		value := valueObj.(map[string]interface{})
+		for k, v := range value {
+			value[k] = m.resolvePlaceholders(sourceModule, source, requires, v)
+		}
+		return value
+	case []interface{}:
+		value := valueObj.([]interface{})
+		for k, v := range value {
+			value[k] = m.resolvePlaceholders(sourceModule, source, requires, v)
+		}
+		return value
+	case string:
+		return m.resolvePlaceholdersString(sourceModule, source, requires, valueObj.(string))
+	default:
+		//if the value is not a string but a leaf, just return it
+		return valueObj
+	}
+}
+
+func (m *MTAResolver) resolvePlaceholdersString(sourceModule *mta.Module, source *mtaSource, requires *mta.Requires, value string) interface{} {
+	pos := 0
+	pos, placeholderName, wholeValue := parseNextVariable(pos, value, placeholderPrefix)
+
+	if pos < 0 {
+		return value
+	}
+	placeholderValue := m.getParameter(sourceModule, source, requires, placeholderName)
+
+	if wholeValue {
+		return placeholderValue
+	}
+	for pos >= 0 {
+		phValueStr := m.embedValue(placeholderPrefix, placeholderName, value, placeholderValue)
+		value = value[:pos] + phValueStr + value[pos+len(placeholderName)+3:]
+		pos, placeholderName, _ = parseNextVariable(pos+len(phValueStr), value, placeholderPrefix)
+		if pos >= 0 {
+			placeholderValue = m.getParameter(sourceModule, source, requires, placeholderName)
+		}
+	}
+
+	return value
+}
+
+// getParameterFromSource returns the value of the parameter in the source scope, and whether it was found.
+// The value keeps the type of the parameter value.
+func (m *MTAResolver) getParameterFromSource(source *mtaSource, paramName string) (interface{}, bool) {
+	if source != nil {
+		paramVal := source.Parameters[paramName]
+		if paramVal != nil {
+			m.sensitive = m.sensitive || source.ParametersMetaData[paramName].Sensitive
+			return m.resolveParameterValue(nil, source, nil, source.Name+"/", paramName, paramVal), true
+		}
+
+		//defaults to context's module params:
+		paramValStr, ok := m.context.modules[source.Name][paramName]
+		if ok {
+			return paramValStr, true
+		}
+
+		//defaults to context's resource params:
+		paramValStr, ok = m.context.resources[source.Name][paramName]
+		if ok {
+			return paramValStr, true
+		}
+	}
+	return nil, false
+}
+
+// getParameter returns the value of the parameter, looked up in the source, requires, module, MTA and global scopes.
+// The value keeps the type of the parameter value; if the parameter is not found, the placeholder is returned.
+func (m *MTAResolver) getParameter(sourceModule *mta.Module, source *mtaSource, requires *mta.Requires, paramName string) interface{} {
+	//first on source parameters scope
+	if paramVal, ok := m.getParameterFromSource(source, paramName); ok {
+		return paramVal
+	}
+
+	//then try on requires level
+	if requires != nil {
+		paramVal := requires.Parameters[paramName]
+		if paramVal != nil {
+			m.sensitive = m.sensitive || requires.ParametersMetaData[paramName].Sensitive
+			return m.resolveParameterValue(sourceModule, source, requires, getRequiresScopeName(sourceModule, requires), paramName, paramVal)
+		}
+	}
+
+	if sourceModule != nil {
+		paramVal := sourceModule.Parameters[paramName]
+		if paramVal != nil {
+			m.sensitive = m.sensitive || sourceModule.ParametersMetaData[paramName].Sensitive
+			return m.resolveParameterValue(sourceModule, source, requires, sourceModule.Name+"/", paramName, paramVal)
+		}
+		//defaults to context's module params:
+		paramValStr, ok := m.context.modules[sourceModule.Name][paramName]
+		if ok {
+			return paramValStr
+		}
+	}
+
+	//then on MTA root scope
+	paramVal := m.Parameters[paramName]
+	if paramVal != nil {
+		m.sensitive = m.sensitive || m.ParametersMetaData[paramName].Sensitive
+		return m.resolveParameterValue(sourceModule, source, requires, "", paramName, paramVal)
+	}
+
+	//then global scope
+	paramValStr, ok := m.context.global[paramName]
+	if ok {
+		return paramValStr
+	}
+
+	if source == nil {
+		m.addDiagnostic(missingParamMsg, paramName, paramName)
+	} else {
+		m.addDiagnostic(missingParamMsg, paramName, source.Name+"/"+paramName)
+	}
+
+	return "${" + paramName + "}"
+}
+
+// resolveParameterValue resolves the placeholders nested in the value of a parameter.
+// The nested placeholders are resolved in the scope of the placeholder that references the parameter,
+// so the parameter is identified in the reference graph by the scope in which it is defined.
+// The resolved value keeps the type of the parameter value. Maps and lists are copied before they are resolved,
+// because the same parameter can be resolved in different scopes.
+func (m *MTAResolver) resolveParameterValue(sourceModule *mta.Module, source *mtaSource, requires *mta.Requires, scopeName, paramName string, value interface{}) interface{} {
+	ref := placeholderPrefix + "{" + scopeName + paramName + "}"
+	graph := m.getGraph()
+	if !graph.enter(ref) {
+		return placeholderPrefix + "{" + paramName + "}"
+	}
+	defer graph.leave(ref)
+
+	return m.resolvePlaceholders(sourceModule, source, requires, copyValue(value))
+}
+
+// markSensitive records the resolved value of the key if it got a value from a sensitive property or parameter,
+// and starts the resolution of the next value
+func (m *MTAResolver) markSensitive(values map[string]interface{}, key string) {
+	if m.sensitive {
+		m.sensitiveValues = append(m.sensitiveValues, sensitiveValue{values, key})
+	}
+	m.sensitive = false
+}
+
+// redactSensitiveValues replaces the resolved values which got a value from a sensitive property or parameter;
+// empty values are kept, like in the redaction of the sensitive properties and parameters
+func (m *MTAResolver) redactSensitiveValues() {
+	for _, sensitive := range m.sensitiveValues {
+		if value, ok := sensitive.values[sensitive.key]; ok && value != nil {
+			sensitive.values[sensitive.key] = mta.RedactedValue
+		}
+	}
+}
+
+// addDiagnostic records a problem found during the resolution which does not prevent it
+func (m *MTAResolver) addDiagnostic(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	for _, diagnostic := range m.diagnostics {
+		if diagnostic == msg {
+			return
+		}
+	}
+	m.diagnostics = append(m.diagnostics, msg)
+}
+
+func (m *MTAResolver) logDiagnostics() {
+	for _, diagnostic := range m.diagnostics {
+		logs.Logger.Warn(diagnostic)
+	}
+}
+
+// Diagnostics returns the problems found during the resolution which did not prevent it,
+// such as a map or a list value embedded in a string
+func (m *MTAResolver) Diagnostics() []string {
+	return m.diagnostics
+}
+
+func getRequiresScopeName(sourceModule *mta.Module, requires *mta.Requires) string {
+	if sourceModule != nil {
+		return sourceModule.Name + "/" + requires.Name + "/"
+	}
+	return requires.Name + "/"
+}
+
+func (m *MTAResolver) findProvider(name string) *mtaSource {
+	for _, module := range m.Modules {
+		for _, provides := range module.Provides {
+			if provides.Name == name {
+				source := mtaSource{Name: module.Name, Properties: provides.Properties, Parameters: module.Parameters,
+					PropertiesMetaData: provides.PropertiesMetaData, ParametersMetaData: module.ParametersMetaData, Type: moduleType, Module: module}
+				return &source
+			}
+		}
+	}
+
+	//in case of resource, its name is the matching to the requires name
+	for _, resource := range m.Resources {
+		if resource.Name == name {
+			source := mtaSource{Name: resource.Name, Properties: resource.Properties, Parameters: resource.Parameters,
+				PropertiesMetaData: resource.PropertiesMetaData, ParametersMetaData: resource.ParametersMetaData, Type: resourceType, Resource: resource}
+			return &source
+		}
+
+	}
+	return nil
+}
+
+// copyValue returns a deep copy of the maps and lists in the value
+func copyValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		res := map[string]interface{}{}
+		for k, v := range v {
+			res[fmt.Sprint(k)] = copyValue(v)
+		}
+		return res
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, v := range v {
+			res[k] = copyValue(v)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for k, v := range v {
+			res[k] = copyValue(v)
+		}
+		return res
+	}
+	return val
+}
+
+func convertToJSONSafe(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		res := map[string]interface{}{}
+		for k, v := range v {
+			res[fmt.Sprint(k)] = convertToJSONSafe(v)
+		}
+		return res
+	case []interface{}:
+		for k, v2 := range v {
+			v[k] = convertToJSONSafe(v2)
+		}
+		return v
+	}
+	return val
+}
@@ -0,0 +1,858 @@
+package resolver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+func callResolveAndGetOutput(wd, moduleName, yamlPath string, extensions []string, envFileName string, env map[string]string) string {
+	reader, writer, err := os.Pipe()
+	Ω(err).Should(Succeed())
+	stdout := os.Stdout
+	stderr := os.Stderr
+	defer func() {
+		os.Stdout = stdout
+		os.Stderr = stderr
+		log.SetOutput(os.Stderr)
+	}()
+	os.Stdout = writer
+	os.Stderr = writer
+	log.SetOutput(writer)
+	out := make(chan string)
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go func() {
+		var buf bytes.Buffer
+		wg.Done()
+		io.Copy(&buf, reader)
+		out <- buf.String()
+	}()
+	wg.Wait()
+	err = Resolve(wd, moduleName, yamlPath, extensions, envFileName, env, false)
+	Ω(err).Should(Succeed())
+	writer.Close()
+	return <-out
+}
+
+func getExpected(expected []string) string {
+	reader, writer, err := os.Pipe()
+	Ω(err).Should(Succeed())
+	stdout := os.Stdout
+	defer func() {
+		os.Stdout = stdout
+	}()
+	os.Stdout = writer
+	log.SetOutput(writer)
+	out := make(chan string)
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go func() {
+		var buf bytes.Buffer
+		wg.Done()
+		io.Copy(&buf, reader)
+		out <- buf.String()
+	}()
+	wg.Wait()
+	for _, exp := range expected {
+		fmt.Println(exp)
+	}
+	writer.Close()
+	return <-out
+}
+
+func callResolveAndValidateOutput(wd, moduleName, yamlPath string, expected []string, envFile string, env map[string]string, extensions ...string) {
+	actualStr := callResolveAndGetOutput(wd, moduleName, yamlPath, extensions, envFile, env)
+	expectedStr := getExpected(expected)
+	Ω(len(actualStr)).Should(Equal(len(expectedStr)))
+	for _, exp := range expected {
+		Ω(actualStr).Should(ContainSubstring(exp))
+	}
+}
+
+var _ = Describe("Resolve", func() {
+	expected := []string{
+		`prop1=no_placeholders`,
+		`prop2=1000m`,
+		`prop3=["1000m","1m"]`,
+		`prop4={"p1":"1000m","p2":"1m"}`,
+		`prop5=1`,
+		`prop6={"1":"1000m"}`,
+		`prop7=~{eb-msahaa/heap`,
+		`prop8=[[{"a":["a1",{"a2-key":"a2-value"}]}]]`,
+		`prop9=vvv`,
+		`prop10=${env_var0}`,
+		`prop11=2G`,
+		`JBP_CONFIG_companyJVM=[ memory_calculator: { memory_sizes: { heap: 1000m, stack: 1m, metaspace: 150m } } ]`,
+		`JBP_CONFIG_companyJVM1=[ memory_calculator: { memory_sizes: { heap: 1000m, stack: 1m, metaspace: 150m } } ]`,
+		`JBP_CONFIG_RESOURCE_CONFIGURATION=[tomcat/webapps/ROOT/META-INF/context.xml: {"service_name_for_DefaultDB" : "ed-aaa-service"}]`,
+	}
+
+	It("Sanity", func() {
+		wd := getTestPath("test-project")
+		yamlPath := getTestPath("test-project", "mta.yaml")
+		callResolveAndValidateOutput(wd, "eb-java", yamlPath, expected, "", mockEnvWithVcapServices())
+	})
+	It("Sanity - working dir not provided", func() {
+		yamlPath := getTestPath("test-project", "mta.yaml")
+		callResolveAndValidateOutput("", "eb-java", yamlPath, expected, "", mockEnvExtWithVcapServices())
+
+	})
+	It("Sanity - environment file name different from the default name (.env)", func() {
+		wd := getTestPath("test-project")
+		yamlPath := getTestPath("test-project", "mta.yaml")
+		expectedResolve := []string{
+			`prop1=no_placeholders`,
+			`prop2=1000m`,
+			`prop3=["1000m","1m"]`,
+			`prop4={"p1":"1000m","p2":"1m"}`,
+			`prop5=1`,
+			`prop6={"1":"1000m"}`,
+			`prop7=~{eb-msahaa/heap`,
+			`prop8=[[{"a":["a1",{"a2-key":"a2-value"}]}]]`,
+			`prop9=newValue`,
+			`prop10=${env_var0}`,
+			`prop11=2G`,
+			`JBP_CONFIG_companyJVM=[ memory_calculator: { memory_sizes: { heap: 1000m, stack: 1m, metaspace: 150m } } ]`,
+			`JBP_CONFIG_companyJVM1=[ memory_calculator: { memory_sizes: { heap: 1000m, stack: 1m, metaspace: 150m } } ]`,
+			`JBP_CONFIG_RESOURCE_CONFIGURATION=[tomcat/webapps/ROOT/META-INF/context.xml: {"service_name_for_DefaultDB" : "ed-aaa-service"}]`,
+		}
+		callResolveAndValidateOutput(wd, "eb-java", yamlPath, expectedResolve, ".env2", mockEnvExtWithVcapServices())
+	})
+	It("Sanity - working dir not provided, no VCAP services", func() {
+		yamlPath := getTestPath("test-project", "mta.yaml")
+		expected[len(expected)-1] = strings.Replace(expected[len(expected)-1], "ed-aaa-service", "${service-name}", -1)
+		callResolveAndValidateOutput("", "eb-java", yamlPath, expected, "", mockEnvExt())
+	})
+	It("Sanity - with MTA extensions", func() {
+		wd := getTestPath("test-project")
+		yamlPath := getTestPath("test-project", "mta.yaml")
+		expectedResolve := []string{
+			`prop1=ext_value`,
+			`prop2=1000m`,
+			`prop3=["1000m","1m"]`,
+			`prop4={"p1":"1000m","p2":"1m"}`,
+			`prop5=1`,
+			`prop6={"1":"1000m"}`,
+			`prop7=~{eb-msahaa/heap`,
+			`prop8=[[{"a":["a1",{"a2-key":"a2-value"}]}]]`,
+			`prop9=vvv`,
+			`prop10=${env_var0}`,
+			`prop11=8G`,
+			`JBP_CONFIG_companyJVM=[ memory_calculator: { memory_sizes: { heap: 1000m, stack: 1m, metaspace: 150m } } ]`,
+			`JBP_CONFIG_companyJVM1=[ memory_calculator: { memory_sizes: { heap: 1000m, stack: 1m, metaspace: 150m } } ]`,
+			`JBP_CONFIG_RESOURCE_CONFIGURATION=[tomcat/webapps/ROOT/META-INF/context.xml: {"service_name_for_DefaultDB" : "ed-aaa-service"}]`,
+		}
+		// the extensions are applied in the order of their "extends" chain
+		callResolveAndValidateOutput(wd, "eb-java", yamlPath, expectedResolve, "", mockEnvWithVcapServices(),
+			getTestPath("test-project", "dev2.mtaext"), getTestPath("test-project", "dev.mtaext"))
+	})
+	It("MTA extension not found", func() {
+		path := getTestPath("test-project", "notExist.mtaext")
+		err := Resolve("", "eb-java", getTestPath("test-project", "mta.yaml"), []string{path}, "", nil, false)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(mergeExtFailsMsg, getTestPath("test-project", "mta.yaml"))))
+	})
+	It("empty module name", func() {
+		err := Resolve("", "", getTestPath("test-project", "mta.yaml"), nil, "", nil, false)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(emptyModuleNameMsg))
+	})
+	It("module not exists", func() {
+		err := Resolve("", "aaa", getTestPath("test-project", "mta.yaml"), nil, "", nil, false)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(fmt.Sprintf(moduleNotFoundMsg, "aaa")))
+	})
+	It("mta yaml path not found", func() {
+		path := getTestPath("test-project", "mtaNotExist.yaml")
+		err := Resolve("", "eb-java", path, nil, "", nil, false)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(pathNotFoundMsg, path)))
+	})
+	It("failure on unmarshal", func() {
+		path := getTestPath("test-project", "mtaBad.yaml")
+		err := Resolve("", "eb-java", path, nil, "", nil, false)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(unmarshalFailsMsg, path)))
+	})
+})
+
+var _ = Describe("getPropertiesAsEnvVar", func() {
+	It("fails on marshalling", func() {
+		mod := mta.Module{
+			Properties: map[string]interface{}{
+				"a": func() {},
+			},
+		}
+		_, err := getPropertiesAsEnvVar(&mod)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(fmt.Sprintf(marshalFailsMag, "a")))
+	})
+	It("required groups defined", func() {
+		mod := mta.Module{
+			Requires: []mta.Requires{
+				{
+					Group: "group1",
+					Properties: map[string]interface{}{
+						"prop1": "value1",
+						"prop2": "value2",
+					},
+				},
+				{
+					Group: "group1",
+					Properties: map[string]interface{}{
+						"prop3": "value3",
+					},
+				},
+				{
+					Properties: map[string]interface{}{
+						"prop4": "value4",
+					},
+				},
+			},
+		}
+		props, err := getPropertiesAsEnvVar(&mod)
+		Ω(err).Should(Succeed())
+		Ω(len(props)).Should(Equal(2))
+		Ω(props["group1"]).Should(Equal(`[{"prop1":"value1","prop2":"value2"},{"prop3":"value3"}]`))
+		Ω(props["prop4"]).Should(Equal(`value4`))
+	})
+})
+
+var _ = Describe("getPropertiesAsEnvVar - list and group", func() {
+	It("required lists defined", func() {
+		mod := mta.Module{
+			Requires: []mta.Requires{
+				{
+					Name: "req1",
+					List: "list1",
+					Properties: map[string]interface{}{
+						"prop1": "value1",
+					},
+				},
+				{
+					Name: "req2",
+					List: "list1",
+					Properties: map[string]interface{}{
+						"prop2": "value2",
+					},
+				},
+				{
+					Name: "req3",
+					List: "list2",
+				},
+			},
+		}
+		props, err := getPropertiesAsEnvVar(&mod)
+		Ω(err).Should(Succeed())
+		Ω(len(props)).Should(Equal(2))
+		Ω(props["list1"]).Should(Equal(`[{"prop1":"value1"},{"prop2":"value2"}]`))
+		Ω(props["list2"]).Should(Equal(`[{}]`))
+	})
+	It("list is used when both list and group are defined", func() {
+		mod := mta.Module{
+			Requires: []mta.Requires{
+				{
+					Name:  "req1",
+					List:  "list1",
+					Group: "group1",
+					Properties: map[string]interface{}{
+						"prop1": "value1",
+					},
+				},
+			},
+		}
+		props, err := getPropertiesAsEnvVar(&mod)
+		Ω(err).Should(Succeed())
+		Ω(props).Should(Equal(map[string]string{"list1": `[{"prop1":"value1"}]`}))
+	})
+	It("fails when a group name collides with a module property", func() {
+		mod := mta.Module{
+			Properties: map[string]interface{}{
+				"destinations": "value",
+			},
+			Requires: []mta.Requires{
+				{
+					Name:  "req1",
+					Group: "destinations",
+				},
+			},
+		}
+		_, err := getPropertiesAsEnvVar(&mod)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(fmt.Sprintf(arrayCollidesWithPropMsg, "destinations", groupKind, "req1")))
+	})
+	It("fails when a required property collides with a group name", func() {
+		mod := mta.Module{
+			Requires: []mta.Requires{
+				{
+					Name:  "req1",
+					Group: "destinations",
+				},
+				{
+					Name: "req2",
+					Properties: map[string]interface{}{
+						"destinations": "value",
+					},
+				},
+			},
+		}
+		_, err := getPropertiesAsEnvVar(&mod)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(fmt.Sprintf(propCollidesWithArrayMsg, "destinations", "req2", groupKind)))
+	})
+	It("fails when a list name collides with a group name", func() {
+		mod := mta.Module{
+			Requires: []mta.Requires{
+				{
+					Name:  "req1",
+					Group: "destinations",
+				},
+				{
+					Name: "req2",
+					List: "destinations",
+				},
+			},
+		}
+		_, err := getPropertiesAsEnvVar(&mod)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(fmt.Sprintf(arrayCollidesWithArrayMsg, "destinations", listKind, "req2", groupKind)))
+	})
+})
+
+var _ = Describe("convertToString", func() {
+	It("fails to marshal function", func() {
+		_, success := convertToString(func() {})
+		Ω(success).Should(BeFalse())
+	})
+	It("map conversion", func() {
+		str, success := convertToString(map[string]interface{}{
+			"field1": "value1",
+			"field2": "value2",
+		})
+		Ω(success).Should(BeTrue())
+		Ω(str).Should(Equal(`{"field1":"value1","field2":"value2"}`))
+	})
+})
+
+var _ = Describe("getParameter", func() {
+	It("parameter found in source parameters", func() {
+		resolver := MTAResolver{}
+		source := mtaSource{
+			Parameters: map[string]interface{}{
+				"param1": "value1",
+			},
+		}
+		res := resolver.getParameter(nil, &source, nil, "param1")
+		Ω(res).Should(Equal("value1"))
+	})
+	It("parameter found in the module (defined by the source) of the context", func() {
+		resolver := MTAResolver{
+			context: &ResolveContext{
+				modules: map[string]map[string]string{
+					"module1": {
+						"param1": "value1",
+					},
+				},
+			},
+		}
+		source := mtaSource{
+			Name: "module1",
+		}
+		res := resolver.getParameter(nil, &source, nil, "param1")
+		Ω(res).Should(Equal("value1"))
+	})
+	It("parameter found in the requires", func() {
+		resolver := MTAResolver{
+			context: &ResolveContext{},
+		}
+		req := mta.Requires{
+			Parameters: map[string]interface{}{
+				"param1": "value1",
+			},
+		}
+
+		res := resolver.getParameter(nil, nil, &req, "param1")
+		Ω(res).Should(Equal("value1"))
+	})
+	It("parameter found in the module (defined by the source module) of the context", func() {
+		resolver := MTAResolver{
+			context: &ResolveContext{
+				modules: map[string]map[string]string{
+					"module1": {
+						"param1": "value1",
+					},
+				},
+			},
+		}
+		mod := mta.Module{
+			Name: "module1",
+		}
+
+		res := resolver.getParameter(&mod, nil, nil, "param1")
+		Ω(res).Should(Equal("value1"))
+	})
+	It("parameter found on the MTA root scope", func() {
+		resolver := MTAResolver{}
+		resolver.Parameters = map[string]interface{}{
+			"param1": "value1",
+		}
+
+		res := resolver.getParameter(nil, nil, nil, "param1")
+		Ω(res).Should(Equal("value1"))
+	})
+	It("parameter is missing", func() {
+		resolver := MTAResolver{
+			context: &ResolveContext{
+				modules: map[string]map[string]string{
+					"module1": {
+						"param1": "value1",
+					},
+				},
+			},
+		}
+		source := mtaSource{
+			Name: "module1",
+		}
+
+		res := resolver.getParameter(nil, &source, nil, "param2")
+		Ω(res).Should(Equal("${param2}"))
+	})
+})
+
+var _ = Describe("typed placeholders", func() {
+	newResolver := func() *MTAResolver {
+		return NewMTAResolver(&mta.MTA{
+			Parameters: map[string]interface{}{
+				"domain": "example.com",
+			},
+			Modules: []*mta.Module{
+				{
+					Name: "module1",
+					Parameters: map[string]interface{}{
+						"instances": 2,
+						"enabled":   true,
+						"limits":    map[interface{}]interface{}{"memory": "${memory}", "disk": 1024},
+						"hosts":     []interface{}{"app.${domain}", 8080},
+						"memory":    "512M",
+					},
+					Properties: map[string]interface{}{
+						"instances":  "${instances}",
+						"enabled":    "${enabled}",
+						"limits":     "${limits}",
+						"hosts":      "${hosts}",
+						"embedded":   "instances=${instances}, enabled=${enabled}",
+						"embeddedV2": "limits=${limits}",
+					},
+				},
+			},
+		}, nil, nil)
+	}
+
+	It("whole value placeholders keep the type of the parameter", func() {
+		m := newResolver()
+		module := m.Modules[0]
+		Ω(m.ResolveProperies(module, "")).Should(Succeed())
+		Ω(module.Properties["instances"]).Should(Equal(2))
+		Ω(module.Properties["enabled"]).Should(Equal(true))
+		Ω(module.Properties["limits"]).Should(Equal(map[string]interface{}{"memory": "512M", "disk": 1024}))
+		Ω(module.Properties["hosts"]).Should(Equal([]interface{}{"app.example.com", 8080}))
+	})
+	It("embedded placeholders are serialized in JSON format", func() {
+		m := newResolver()
+		module := m.Modules[0]
+		Ω(m.ResolveProperies(module, "")).Should(Succeed())
+		Ω(module.Properties["embedded"]).Should(Equal("instances=2, enabled=true"))
+		Ω(module.Properties["embeddedV2"]).Should(Equal(`limits={"disk":1024,"memory":"512M"}`))
+	})
+	It("reports embedded maps and lists as diagnostics", func() {
+		m := newResolver()
+		Ω(m.ResolveProperies(m.Modules[0], "")).Should(Succeed())
+		Ω(m.Diagnostics()).Should(Equal([]string{
+			fmt.Sprintf(embeddedValueTypeMsg, "${limits}", "limits=${limits}", mapKind),
+		}))
+	})
+	It("does not change the parameter when it is resolved", func() {
+		m := newResolver()
+		Ω(m.ResolveProperies(m.Modules[0], "")).Should(Succeed())
+		Ω(m.Modules[0].Parameters["limits"]).Should(Equal(map[interface{}]interface{}{"memory": "${memory}", "disk": 1024}))
+		Ω(m.Modules[0].Parameters["hosts"]).Should(Equal([]interface{}{"app.${domain}", 8080}))
+	})
+	It("gets a parameter which is not a string", func() {
+		resolver := MTAResolver{}
+		source := mtaSource{
+			Parameters: map[string]interface{}{
+				"param1": 1,
+			},
+		}
+		Ω(resolver.getParameter(nil, &source, nil, "param1")).Should(Equal(1))
+	})
+})
+
+var _ = Describe("findProvider", func() {
+	It("provider not found", func() {
+		resolver := MTAResolver{}
+		Ω(resolver.findProvider("provider")).Should(BeNil())
+	})
+})
+
+var _ = Describe("resolvePlaceholdersString", func() {
+	It("all placeholders resolved", func() {
+		resolver := MTAResolver{
+			context: &ResolveContext{
+				global: map[string]string{
+					"p1": "value1",
+					"p2": "value2",
+				},
+			},
+		}
+		value := resolver.resolvePlaceholdersString(nil, nil, nil, "${p1}/${p2}")
+		Ω(value).Should(Equal("value1/value2"))
+	})
+	It("reports the missing parameters as diagnostics", func() {
+		resolver := MTAResolver{
+			context: &ResolveContext{global: map[string]string{}},
+		}
+		value := resolver.resolvePlaceholdersString(nil, &mtaSource{Name: "module1"}, nil, "${p1}/${p1}")
+		Ω(value).Should(Equal("${p1}/${p1}"))
+		Ω(resolver.Diagnostics()).Should(Equal([]string{fmt.Sprintf(missingParamMsg, "p1", "module1/p1")}))
+	})
+})
+
+var _ = Describe("resolvePlaceholders", func() {
+	It("value is a map[interface{}]interface{} ", func() {
+		resolver := MTAResolver{
+			context: &ResolveContext{
+				global: map[string]string{
+					"p1": "value1",
+					"p2": "value2",
+				},
+			},
+		}
+		value := map[interface{}]interface{}{
+			"abc": "${p1}",
+		}
+		res := resolver.resolvePlaceholders(nil, nil, nil, value)
+		Ω(res).Should(BeEquivalentTo(map[string]interface{}{"abc": "value1"}))
+	})
+})
+
+var _ = Describe("getVariableValue", func() {
+	It("missing required prefix", func() {
+		resolver := MTAResolver{}
+		res := resolver.getVariableValue(nil, nil, "var_without_prefix")
+		Ω(res).Should(Equal("~{var_without_prefix}"))
+	})
+	It("missing configuration", func() {
+		resolver := MTAResolver{}
+		resolver.Resources = []*mta.Resource{
+			{
+				Name:       "provider",
+				Type:       "configuration",
+				Properties: map[string]interface{}{},
+				Parameters: map[string]interface{}{
+					"provider-id": "id",
+				},
+			},
+		}
+		res := resolver.getVariableValue(nil, nil, "provider/var")
+		Ω(res).Should(Equal("~{var}"))
+		Ω(resolver.Diagnostics()).Should(Equal([]string{fmt.Sprintf(missingConfigMsg, "var", "provider", "id")}))
+	})
+	It("missing configuration with a provider ID which is not a string", func() {
+		resolver := MTAResolver{}
+		resolver.Resources = []*mta.Resource{
+			{
+				Name:       "provider",
+				Type:       "configuration",
+				Properties: map[string]interface{}{},
+				Parameters: map[string]interface{}{
+					"provider-id": 1,
+				},
+			},
+		}
+		res := resolver.getVariableValue(nil, nil, "provider/var")
+		Ω(res).Should(Equal("~{var}"))
+		Ω(resolver.Diagnostics()).Should(BeEmpty())
+	})
+})
+
+var _ = Describe("parseNextVariable", func() {
+	It("prefix at the end of the value", func() {
+		start, _, _ := parseNextVariable(0, "a ~{", "~")
+		Ω(start).Should(Equal(-1))
+	})
+	It("double end sign", func() {
+		start, end, whole := parseNextVariable(0, "a ~{{var1}}", "~")
+		Ω(start).Should(Equal(2))
+		Ω(end).Should(Equal("{var1}"))
+		Ω(whole).Should(BeFalse())
+	})
+})
+
+var _ = Describe("GetVariables and GetPlaceholders", func() {
+	It("returns the names of the variables", func() {
+		Ω(GetVariables("~{a/b}://~{{c}}/${d}")).Should(Equal([]string{"a/b", "{c}"}))
+	})
+	It("returns the names of the placeholders", func() {
+		Ω(GetPlaceholders("${default-url}/~{a/b}/${{e}}")).Should(Equal([]string{"default-url", "{e}"}))
+	})
+	It("ignores a reference which is not closed", func() {
+		Ω(GetVariables("a ~{b")).Should(BeNil())
+	})
+})
+
+func mockEnv() map[string]string {
+	return EnvironmentFromList([]string{"health-check-type=http"})
+}
+
+func mockEnvWithVcapServices() map[string]string {
+	vs := vcapServices{"aaa": []VcapService{
+		{Name: "ed-aaa-service", InstanceName: "aaa", Label: "aaa", Plan: "aaa", Tags: []string{"mta-resource-name:ed-aaa"}},
+		{Name: "ed-bbb-service", InstanceName: "bbb", Label: "bbb", Plan: "bbb", Tags: []string{"mta-resource-name:ed-bbb"}},
+	}}
+	vsByte, _ := json.Marshal(vs)
+	vsStr := string(vsByte)
+	return EnvironmentFromList([]string{"health-check-type=http", "VCAP_SERVICES=" + vsStr})
+}
+
+// when working dir not provided .env file not found and it's variable are missing
+// to complete the list of environment variables this function is used
+func mockEnvExtWithVcapServices() map[string]string {
+	result := mockEnvWithVcapServices()
+	result["env_var1"] = "vvv"
+	return result
+}
+
+func mockEnvExt() map[string]string {
+	result := mockEnv()
+	result["env_var1"] = "vvv"
+	return result
+}
+
+var _ = Describe("nested references", func() {
+	It("resolves placeholders nested in parameters", func() {
+		resolver := NewMTAResolver(&mta.MTA{
+			Parameters: map[string]interface{}{
+				"domain": "example.com",
+			},
+			Modules: []*mta.Module{
+				{
+					Name: "module1",
+					Parameters: map[string]interface{}{
+						"host": "app-${space}",
+						"url":  "https://${host}.${domain}",
+					},
+					Properties: map[string]interface{}{
+						"prop1": "${url}",
+					},
+				},
+			},
+		}, nil, nil)
+		resolver.context.global["space"] = "dev"
+		module := resolver.Modules[0]
+		Ω(resolver.ResolveProperies(module, "")).Should(Succeed())
+		Ω(module.Properties["prop1"]).Should(Equal("https://app-dev.example.com"))
+	})
+	It("resolves variables nested in provided properties", func() {
+		resolver := NewMTAResolver(&mta.MTA{
+			Modules: []*mta.Module{
+				{
+					Name: "module1",
+					Properties: map[string]interface{}{
+						"prop1": "~{provider1/url}",
+					},
+				},
+				{
+					Name: "module2",
+					Provides: []mta.Provides{
+						{
+							Name: "provider1",
+							Properties: map[string]interface{}{
+								"url": "~{provider2/protocol}://host",
+							},
+						},
+						{
+							Name: "provider2",
+							Properties: map[string]interface{}{
+								"protocol": "https",
+							},
+						},
+					},
+				},
+			},
+		}, nil, nil)
+		module := resolver.Modules[0]
+		Ω(resolver.ResolveProperies(module, "")).Should(Succeed())
+		Ω(module.Properties["prop1"]).Should(Equal("https://host"))
+	})
+	It("reports cyclic placeholders with the reference path", func() {
+		resolver := NewMTAResolver(&mta.MTA{
+			Modules: []*mta.Module{
+				{
+					Name: "module1",
+					Parameters: map[string]interface{}{
+						"a": "${b}",
+						"b": "x-${a}",
+					},
+					Properties: map[string]interface{}{
+						"prop1": "${a}",
+					},
+				},
+			},
+		}, nil, nil)
+		module := resolver.Modules[0]
+		err := resolver.ResolveProperies(module, "")
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(fmt.Sprintf(cyclicReferenceMsg, "${module1/a}", "${module1/a} -> ${module1/b} -> ${module1/a}")))
+		Ω(module.Properties["prop1"]).Should(Equal("x-${a}"))
+	})
+	It("reports mutually referencing provided properties with the reference path", func() {
+		resolver := NewMTAResolver(&mta.MTA{
+			Modules: []*mta.Module{
+				{
+					Name: "module1",
+					Properties: map[string]interface{}{
+						"prop1": "~{provider1/a}",
+					},
+					Provides: []mta.Provides{
+						{
+							Name: "provider1",
+							Properties: map[string]interface{}{
+								"a": "~{provider2/b}",
+							},
+						},
+						{
+							Name: "provider2",
+							Properties: map[string]interface{}{
+								"b": "~{provider1/a}",
+							},
+						},
+					},
+				},
+			},
+		}, nil, nil)
+		module := resolver.Modules[0]
+		err := resolver.ResolveProperies(module, "")
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(fmt.Sprintf(cyclicReferenceMsg, "~{provider1/a}", "~{provider1/a} -> ~{provider2/b} -> ~{provider1/a}")))
+	})
+	It("Resolve fails on cyclic references", func() {
+		err := Resolve("", "eb-java", getTestPath("test-project", "mtaCyclic.yaml"), nil, "", nil, false)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(cyclicReferenceMsg, "${eb-java/memory}", "${eb-java/memory} -> ${eb-java/memory}")))
+	})
+})
+
+var _ = Describe("Sensitive properties", func() {
+	newResolver := func() *MTAResolver {
+		return NewMTAResolver(&mta.MTA{
+			Modules: []*mta.Module{
+				{
+					Name: "module1",
+					Properties: map[string]interface{}{
+						"user":     "admin",
+						"password": "${db-password}",
+					},
+					PropertiesMetaData: map[string]mta.MetaData{
+						"password": {Sensitive: true},
+					},
+				},
+			},
+		}, map[string]string{"db-password": "secret"}, nil)
+	}
+
+	It("redacts the sensitive properties in the module environment", func() {
+		env, err := newResolver().resolveModuleEnv("module1", "", false)
+		Ω(err).Should(Succeed())
+		Ω(env).Should(Equal(map[string]string{"user": "admin", "password": mta.RedactedValue}))
+	})
+
+	It("reveals the sensitive properties in the module environment", func() {
+		env, err := newResolver().ResolveModuleEnv("module1", "")
+		Ω(err).Should(Succeed())
+		Ω(env).Should(Equal(map[string]string{"user": "admin", "password": "secret"}))
+	})
+	newDerivedResolver := func() *MTAResolver {
+		return NewMTAResolver(&mta.MTA{
+			Modules: []*mta.Module{
+				{
+					Name: "module1",
+					Properties: map[string]interface{}{
+						"url":            "~{db/url}",
+						"connection":     "user=~{db/user};password=~{db/password}",
+						"api-key-header": "Bearer ${api-key}",
+						"api-url":        "${api-url}",
+					},
+					Parameters: map[string]interface{}{
+						"api-key": "key",
+						"api-url": "https://api.example.com",
+					},
+					ParametersMetaData: map[string]mta.MetaData{
+						"api-key": {Sensitive: true},
+					},
+					Requires: []mta.Requires{{Name: "db"}},
+				},
+			},
+			Resources: []*mta.Resource{
+				{
+					Name: "db",
+					Properties: map[string]interface{}{
+						"url":      "db.example.com",
+						"user":     "admin",
+						"password": "${password}",
+					},
+					Parameters: map[string]interface{}{
+						"password": "secret",
+					},
+					PropertiesMetaData: map[string]mta.MetaData{
+						"password": {Sensitive: true},
+					},
+				},
+			},
+		}, nil, nil)
+	}
+
+	It("redacts the values which get a value from a sensitive provided property or parameter", func() {
+		env, err := newDerivedResolver().resolveModuleEnv("module1", "", false)
+		Ω(err).Should(Succeed())
+		Ω(env).Should(Equal(map[string]string{
+			"url":            "db.example.com",
+			"connection":     mta.RedactedValue,
+			"api-key-header": mta.RedactedValue,
+			"api-url":        "https://api.example.com",
+		}))
+	})
+
+	It("reveals the values which get a value from a sensitive provided property or parameter", func() {
+		env, err := newDerivedResolver().ResolveModuleEnv("module1", "")
+		Ω(err).Should(Succeed())
+		Ω(env["connection"]).Should(Equal("user=admin;password=secret"))
+		Ω(env["api-key-header"]).Should(Equal("Bearer key"))
+	})
+
+	It("redacts the values which get a value from a sensitive source in the resolved MTA", func() {
+		m := newDerivedResolver()
+		resolved, err := m.ResolveMTA("")
+		Ω(err).Should(Succeed())
+		resolved.Redact()
+		m.redactSensitiveValues()
+		module := resolved.Modules[0]
+		Ω(module.Properties["connection"]).Should(Equal(mta.RedactedValue))
+		Ω(module.Properties["api-key-header"]).Should(Equal(mta.RedactedValue))
+		Ω(module.Properties["url"]).Should(Equal("db.example.com"))
+		Ω(module.Parameters["api-url"]).Should(Equal("https://api.example.com"))
+		Ω(resolved.Resources[0].Properties["password"]).Should(Equal(mta.RedactedValue))
+		Ω(resolved.Resources[0].Properties["user"]).Should(Equal("admin"))
+	})
+})
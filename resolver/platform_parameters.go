@@ -20,6 +20,42 @@ type VcapService struct {
 
 const tagResourceNamePrefix = "mta-resource-name:"
 
+// platformParameters are the parameters which are set by the deployer, so they can be used in placeholders
+// without being defined in the MTA
+var platformParameters = map[string]bool{
+	"app-name":             true,
+	"authorization-url":    true,
+	"controller-url":       true,
+	"default-app-name":     true,
+	"default-domain":       true,
+	"default-host":         true,
+	"default-instances":    true,
+	"default-port":         true,
+	"default-service-name": true,
+	"default-uri":          true,
+	"default-url":          true,
+	"default-xsappname":    true,
+	"deploy-url":           true,
+	"domain":               true,
+	"generated-password":   true,
+	"generated-user":       true,
+	"host":                 true,
+	"instances":            true,
+	"org":                  true,
+	"port":                 true,
+	"protocol":             true,
+	"service-name":         true,
+	"space":                true,
+	"user":                 true,
+	"xs-type":              true,
+	"xsappname":            true,
+}
+
+// IsPlatformParameter returns true if the parameter is set by the deployer, like "default-url" or "org"
+func IsPlatformParameter(name string) bool {
+	return platformParameters[name]
+}
+
 // ResolveContext holds context ifno during resolving of properties
 type ResolveContext struct {
 	global    map[string]string
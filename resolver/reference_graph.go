@@ -0,0 +1,82 @@
+package resolver
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	cyclicReferenceMsg       = `could not resolve the "%s" reference; it is part of a cyclic reference: %s`
+	referenceDepthExceedsMsg = `could not resolve the "%s" reference; the maximum nesting depth of %d references was exceeded: %s`
+
+	// maxReferenceDepth is the maximum number of nested references resolved for a single value
+	maxReferenceDepth = 64
+
+	referencePathSeparator = " -> "
+)
+
+// referenceGraph tracks the variables (~{...}) and placeholders (${...}) that are resolved.
+// References are resolved depth first, when they are found, so that the references a value depends on are resolved
+// before the value itself. A reference that is reached again while it is still being resolved closes a cycle;
+// the cycle is reported with the full reference path and the reference is left unresolved.
+type referenceGraph struct {
+	// path holds the references that are currently being resolved, from the outermost to the innermost
+	path []string
+	// resolved holds the values of references that were already resolved and do not depend on the resolution scope
+	resolved map[string]interface{}
+	// errors holds the errors found during the resolution, without duplicates
+	errors   []error
+	messages map[string]bool
+}
+
+func newReferenceGraph() *referenceGraph {
+	return &referenceGraph{
+		resolved: map[string]interface{}{},
+		messages: map[string]bool{},
+	}
+}
+
+// enter marks the reference as being resolved. It returns false if the reference cannot be resolved
+// because it closes a cycle or is nested too deep; in this case an error is recorded.
+func (g *referenceGraph) enter(ref string) bool {
+	for i, pathRef := range g.path {
+		if pathRef == ref {
+			cycle := append(append([]string{}, g.path[i:]...), ref)
+			g.addError(errors.Errorf(cyclicReferenceMsg, ref, strings.Join(cycle, referencePathSeparator)))
+			return false
+		}
+	}
+	if len(g.path) >= maxReferenceDepth {
+		path := append(append([]string{}, g.path...), ref)
+		g.addError(errors.Errorf(referenceDepthExceedsMsg, ref, maxReferenceDepth, strings.Join(path, referencePathSeparator)))
+		return false
+	}
+
+	g.path = append(g.path, ref)
+	return true
+}
+
+// leave marks the reference, which must be the innermost reference being resolved, as resolved
+func (g *referenceGraph) leave(ref string) {
+	g.path = g.path[:len(g.path)-1]
+}
+
+func (g *referenceGraph) addError(err error) {
+	if !g.messages[err.Error()] {
+		g.messages[err.Error()] = true
+		g.errors = append(g.errors, err)
+	}
+}
+
+// err returns a single error describing all the errors found during the resolution, or nil if there are none
+func (g *referenceGraph) err() error {
+	if len(g.errors) == 0 {
+		return nil
+	}
+	messages := make([]string, len(g.errors))
+	for i, err := range g.errors {
+		messages[i] = err.Error()
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
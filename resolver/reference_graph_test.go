@@ -0,0 +1,43 @@
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("referenceGraph", func() {
+	It("resolves nested references", func() {
+		graph := newReferenceGraph()
+		Ω(graph.enter("a")).Should(BeTrue())
+		Ω(graph.enter("b")).Should(BeTrue())
+		graph.leave("b")
+		Ω(graph.enter("c")).Should(BeTrue())
+		graph.leave("c")
+		graph.leave("a")
+		Ω(graph.path).Should(BeEmpty())
+		Ω(graph.err()).Should(Succeed())
+	})
+	It("reports each cycle once", func() {
+		graph := newReferenceGraph()
+		Ω(graph.enter("a")).Should(BeTrue())
+		Ω(graph.enter("b")).Should(BeTrue())
+		Ω(graph.enter("a")).Should(BeFalse())
+		Ω(graph.enter("a")).Should(BeFalse())
+		Ω(graph.err()).Should(MatchError(fmt.Sprintf(cyclicReferenceMsg, "a", "a -> b -> a")))
+	})
+	It("reports references nested too deep", func() {
+		graph := newReferenceGraph()
+		var path []string
+		for i := 0; i < maxReferenceDepth; i++ {
+			ref := fmt.Sprint(i)
+			path = append(path, ref)
+			Ω(graph.enter(ref)).Should(BeTrue())
+		}
+		Ω(graph.enter("last")).Should(BeFalse())
+		path = append(path, "last")
+		Ω(graph.err()).Should(MatchError(fmt.Sprintf(referenceDepthExceedsMsg, "last", maxReferenceDepth, strings.Join(path, referencePathSeparator))))
+	})
+})
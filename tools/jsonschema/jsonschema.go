@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta/validations"
+)
+
+// This code is executed during the go:generate command
+// Reading the MTA schema file and generate the JSON Schema used by the IDE
+func main() {
+	inFile := flag.String("source", "", "source")
+	outFile := flag.String("target", "", "target")
+	flag.Parse()
+	err := genJSONSchema(*inFile, *outFile)
+	if err != nil {
+		fmt.Println(err.Error())
+		panic(err.Error())
+	}
+}
+
+func genJSONSchema(source, target string) error {
+	inData, err := ioutil.ReadFile(source)
+	if err != nil {
+		return errors.Wrapf(err, "JSON Schema generation failed when reading the %s file", source)
+	}
+	outData, err := validate.BuildJSONSchema(inData, filepath.Base(source))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(target, outData, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "JSON Schema generation failed when writing the %s file", target)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package validate
+
+import (
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	codeActionsReadFailedMsg = `could not read the "%s" file; could not get the code actions`
+
+	quickFixKind     = "quickfix"
+	diagnosticSource = "mta"
+	errorSeverity    = 1
+	warningSeverity  = 2
+	fileURIScheme    = "file"
+)
+
+// Position - a 0-based position in a document, as defined by the Language Server Protocol;
+// the character is counted in UTF-16 code units
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range - a range in a document, as defined by the Language Server Protocol; the end position is not included
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// TextEdit - a change of the text of a document, as defined by the Language Server Protocol
+type TextEdit struct {
+	Range   Range  `json:"range"`
+	NewText string `json:"newText"`
+}
+
+// WorkspaceEdit - the changes of the documents, by their URI, as defined by the Language Server Protocol
+type WorkspaceEdit struct {
+	Changes map[string][]TextEdit `json:"changes"`
+}
+
+// Diagnostic - a validation issue, as defined by the Language Server Protocol
+type Diagnostic struct {
+	Range    Range  `json:"range"`
+	Severity int    `json:"severity"`
+	Source   string `json:"source"`
+	Message  string `json:"message"`
+}
+
+// CodeAction - a quick fix of a diagnostic, as defined by the Language Server Protocol
+type CodeAction struct {
+	Title       string        `json:"title"`
+	Kind        string        `json:"kind"`
+	Diagnostics []Diagnostic  `json:"diagnostics"`
+	IsPreferred bool          `json:"isPreferred"`
+	Edit        WorkspaceEdit `json:"edit"`
+}
+
+// GetCodeActions returns the quick fixes of the issues found by the validations of the MTA.yaml file as code actions
+// of the Language Server Protocol. The edits of the code actions refer to the document URI; when it is empty,
+// the file URI of the MTA.yaml file is used.
+func GetCodeActions(projectPath, mtaFilename, uri, schemaVersion string, strict bool, exclude string) ([]CodeAction, error) {
+	mtaPath := filepath.Join(projectPath, mtaFilename)
+	content, err := ioutil.ReadFile(mtaPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, codeActionsReadFailedMsg, mtaPath)
+	}
+	if len(uri) == 0 {
+		uri, err = getFileURI(mtaPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	yamlContent := []byte(strings.Replace(string(content), "\r\n", "\r", -1))
+	errIssues, warnIssues := validateWithSchemaVersion(yamlContent, projectPath, mtaFilename, schemaVersion,
+		true, true, strict, exclude)
+	errIssues.Sort()
+	warnIssues.Sort()
+
+	lines := splitLines(string(content))
+	actions := []CodeAction{}
+	for _, issue := range errIssues {
+		actions = appendCodeAction(actions, lines, issue, errorSeverity, uri)
+	}
+	for _, issue := range warnIssues {
+		actions = appendCodeAction(actions, lines, issue, warningSeverity, uri)
+	}
+	return actions, nil
+}
+
+// appendCodeAction adds the code action of the issue, if it has a fix
+func appendCodeAction(actions []CodeAction, lines []string, issue YamlValidationIssue, severity int, uri string) []CodeAction {
+	if issue.Fix == nil || issue.Line < 1 {
+		return actions
+	}
+	diagnostic := Diagnostic{
+		Range:    Range{Start: Position{Line: issue.Line - 1}, End: Position{Line: issue.Line}},
+		Severity: severity,
+		Source:   diagnosticSource,
+		Message:  issue.Msg,
+	}
+	var edits []TextEdit
+	for _, edit := range issue.Fix.Edits {
+		edits = append(edits, TextEdit{
+			Range: Range{
+				Start: Position{Line: edit.Line - 1, Character: getCharacter(lines, edit.Line, edit.Column)},
+				End:   Position{Line: edit.EndLine - 1, Character: getCharacter(lines, edit.EndLine, edit.EndColumn)},
+			},
+			NewText: edit.NewText,
+		})
+	}
+	return append(actions, CodeAction{
+		Title:       issue.Fix.Title,
+		Kind:        quickFixKind,
+		Diagnostics: []Diagnostic{diagnostic},
+		IsPreferred: true,
+		Edit:        WorkspaceEdit{Changes: map[string][]TextEdit{uri: edits}},
+	})
+}
+
+// getCharacter returns the 0-based character of the 1-based position, counted in UTF-16 code units
+// like in the Language Server Protocol; the column of the position is counted in runes
+func getCharacter(lines []string, line, column int) int {
+	if line < 1 || line > len(lines) {
+		return column - 1
+	}
+	character := 0
+	runes := 0
+	for _, r := range lines[line-1] {
+		if runes == column-1 {
+			return character
+		}
+		runes++
+		// the runes outside of the Basic Multilingual Plane are encoded as surrogate pairs in UTF-16
+		if r > 0xFFFF {
+			character += 2
+		} else {
+			character++
+		}
+	}
+	return character + column - 1 - runes
+}
+
+// getFileURI returns the file URI of the path
+func getFileURI(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	absPath = filepath.ToSlash(absPath)
+	// Windows paths start with the volume name
+	if !strings.HasPrefix(absPath, "/") {
+		absPath = "/" + absPath
+	}
+	uri := url.URL{Scheme: fileURIScheme, Path: absPath}
+	return uri.String(), nil
+}
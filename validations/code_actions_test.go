@@ -0,0 +1,102 @@
+package validate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetCodeActions", func() {
+	var dir string
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "actions")
+		Ω(err).Should(Succeed())
+		Ω(ioutil.WriteFile(filepath.Join(dir, "mta.yaml"), []byte(`ID: mta
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+  - name: a
+    type: java
+    build-parameters:
+      no-source: "true"
+`), 0644)).Should(Succeed())
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("returns the quick fixes of the issues with 0-based positions", func() {
+		actions, err := GetCodeActions(dir, "mta.yaml", "file:///project/mta.yaml", "", true, "")
+		Ω(err).Should(Succeed())
+		Ω(actions).Should(Equal([]CodeAction{{
+			Title: noSourceFixTitle,
+			Kind:  quickFixKind,
+			Diagnostics: []Diagnostic{{
+				Range:    Range{Start: Position{Line: 8}, End: Position{Line: 9}},
+				Severity: errorSeverity,
+				Source:   diagnosticSource,
+				Message:  `the "no-source" build parameter must be a boolean`,
+			}},
+			IsPreferred: true,
+			Edit: WorkspaceEdit{Changes: map[string][]TextEdit{"file:///project/mta.yaml": {{
+				Range:   Range{Start: Position{Line: 8, Character: 17}, End: Position{Line: 8, Character: 23}},
+				NewText: "true",
+			}}}},
+		}}))
+	})
+
+	It("uses the file URI of the MTA file by default", func() {
+		actions, err := GetCodeActions(dir, "mta.yaml", "", "", false, "")
+		Ω(err).Should(Succeed())
+		Ω(len(actions)).Should(Equal(1))
+		for uri := range actions[0].Edit.Changes {
+			Ω(strings.HasPrefix(uri, "file:///")).Should(BeTrue())
+			Ω(strings.HasSuffix(uri, "/mta.yaml")).Should(BeTrue())
+		}
+	})
+
+	It("counts the characters of the positions in UTF-16 code units", func() {
+		Ω(ioutil.WriteFile(filepath.Join(dir, "mta.yaml"), []byte(`ID: mta
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+  - name: a
+    type: java
+    build-parameters: {"😀é": a, no-source: "true"}
+`), 0644)).Should(Succeed())
+		actions, err := GetCodeActions(dir, "mta.yaml", "file:///project/mta.yaml", "", true, "")
+		Ω(err).Should(Succeed())
+		Ω(len(actions)).Should(Equal(1))
+		Ω(actions[0].Edit.Changes["file:///project/mta.yaml"]).Should(Equal([]TextEdit{{
+			Range:   Range{Start: Position{Line: 7, Character: 44}, End: Position{Line: 7, Character: 50}},
+			NewText: "true",
+		}}))
+	})
+
+	It("escapes the path in the file URI of the MTA file", func() {
+		projectDir := filepath.Join(dir, "my project#1")
+		Ω(os.Mkdir(projectDir, 0755)).Should(Succeed())
+		content, err := ioutil.ReadFile(filepath.Join(dir, "mta.yaml"))
+		Ω(err).Should(Succeed())
+		Ω(ioutil.WriteFile(filepath.Join(projectDir, "mta.yaml"), content, 0644)).Should(Succeed())
+		actions, err := GetCodeActions(projectDir, "mta.yaml", "", "", false, "")
+		Ω(err).Should(Succeed())
+		Ω(len(actions)).Should(Equal(1))
+		for uri := range actions[0].Edit.Changes {
+			Ω(strings.HasPrefix(uri, "file:///")).Should(BeTrue())
+			Ω(strings.HasSuffix(uri, "/my%20project%231/mta.yaml")).Should(BeTrue())
+		}
+	})
+
+	It("fails when the file does not exist", func() {
+		_, err := GetCodeActions(dir, "mta1.yaml", "", "", true, "")
+		Ω(err).Should(HaveOccurred())
+	})
+})
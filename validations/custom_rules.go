@@ -0,0 +1,305 @@
+package validate
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	customRuleIssueMsg       = `the "%s" %s does not comply with the "%s" rule: %s`
+	customRuleMissingMsg     = `the "%s" value is missing`
+	customRuleEqualsMsg      = `the "%s" value must be "%s"`
+	customRuleNotEqualsMsg   = `the "%s" value must not be "%s"`
+	customRuleOneOfMsg       = `the "%s" value must be one of the following values: %s`
+	customRuleNotOneOfMsg    = `the "%s" value must not be one of the following values: %s`
+	customRulePatternMsg     = `the "%s" value must match the "%s" pattern`
+	customRuleMinMsg         = `the "%s" value must be at least "%s"`
+	customRuleMaxMsg         = `the "%s" value must be at most "%s"`
+	customRuleNotQuantityMsg = `the "%s" value "%s" is not a number or a size`
+
+	customRuleIDMsg        = `the custom rule %d in the "%s" rule configuration file must have an "id"`
+	customRuleDuplicateMsg = `the "%s" custom rule in the "%s" rule configuration file is already defined`
+	customRuleInvalidMsg   = `the "%s" custom rule in the "%s" rule configuration file is not valid: %s`
+	customRuleKindMsg      = `the "%s" kind is not supported; use "module" or "resource"`
+	customRuleSeverityMsg  = `the "%s" severity is not supported; use "warn" or "error"`
+	customRulePathMsg      = `a condition must have a "path"`
+	customRulePatternErr   = `the "%s" pattern is not a valid regular expression`
+	customRuleQuantityErr  = `the "%s" limit is not a number or a size`
+)
+
+// The units of the sizes, like the memory and the disk quota of the applications, are powers of 1024
+var (
+	quantityRegex = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)\s*([KkMmGgTt]?)[Bb]?$`)
+	quantityUnits = map[string]float64{"K": 1 << 10, "M": 1 << 20, "G": 1 << 30, "T": 1 << 40}
+)
+
+// customRule - a validation rule declared by the teams in the rule configuration file. The rule checks the conditions
+// on the modules or resources it selects.
+type customRule struct {
+	// ID - the ID of the rule, used in the issues, in the "rules" configuration and in the suppression comments
+	ID string `yaml:"id"`
+	// Message - the description of the rule, reported instead of the failed condition
+	Message string `yaml:"message"`
+	// Severity - "error" by default, or "warn"
+	Severity string `yaml:"severity"`
+	// Descriptors - the patterns of the names of the descriptor files the rule applies to; all of them by default
+	Descriptors []string `yaml:"descriptors"`
+	// Select - the modules or resources the rule applies to
+	Select customRuleSelector `yaml:"select"`
+	// Assert - the conditions the selected modules or resources must meet
+	Assert []customRuleCondition `yaml:"assert"`
+}
+
+// customRuleSelector - selects the modules or resources of a kind by their types, names and conditions
+type customRuleSelector struct {
+	// Kind - "module" or "resource"
+	Kind string `yaml:"kind"`
+	// Type - the pattern of the type
+	Type string `yaml:"type"`
+	// Name - the pattern of the name
+	Name string `yaml:"name"`
+	// Where - the conditions the module or resource must meet to be selected; a missing value or a value with
+	// placeholders or variables does not meet them
+	Where []customRuleCondition `yaml:"where"`
+}
+
+// customRuleCondition - a condition on the value in a path of a module or a resource, for example "parameters.memory".
+// In the assertions, except "required", the conditions do not apply to missing values and to values
+// with placeholders or variables.
+type customRuleCondition struct {
+	Path     string   `yaml:"path"`
+	Required bool     `yaml:"required"`
+	Equals   *string  `yaml:"equals"`
+	NotEqual *string  `yaml:"not-equals"`
+	OneOf    []string `yaml:"one-of"`
+	NotOneOf []string `yaml:"not-one-of"`
+	Pattern  string   `yaml:"pattern"`
+	// Min - the minimum number or size, for example "256M"
+	Min string `yaml:"min"`
+	// Max - the maximum number or size, for example "1G"
+	Max string `yaml:"max"`
+}
+
+// validateCustomRules checks the definitions of the custom rules; the IDs of the rules must not be used by other rules
+func validateCustomRules(rules []customRule, configPath string) []YamlValidationIssue {
+	var issues []YamlValidationIssue
+	ruleNames := getRuleNames()
+	for i, rule := range rules {
+		if len(rule.ID) == 0 {
+			issues = append(issues, YamlValidationIssue{Msg: fmt.Sprintf(customRuleIDMsg, i+1, configPath)})
+			continue
+		}
+		if containsString(ruleNames, rule.ID) {
+			issues = append(issues, YamlValidationIssue{Msg: fmt.Sprintf(customRuleDuplicateMsg, rule.ID, configPath)})
+			continue
+		}
+		ruleNames = append(ruleNames, rule.ID)
+		for _, problem := range rule.getProblems() {
+			issues = append(issues, YamlValidationIssue{Msg: fmt.Sprintf(customRuleInvalidMsg, rule.ID, configPath, problem)})
+		}
+	}
+	return issues
+}
+
+func (r *customRule) getProblems() []string {
+	var problems []string
+	if r.Select.Kind != moduleEntityKind && r.Select.Kind != resourceEntityKind {
+		problems = append(problems, fmt.Sprintf(customRuleKindMsg, r.Select.Kind))
+	}
+	if len(r.Severity) > 0 && r.Severity != severityWarn && r.Severity != severityError {
+		problems = append(problems, fmt.Sprintf(customRuleSeverityMsg, r.Severity))
+	}
+	for _, condition := range append(append([]customRuleCondition{}, r.Select.Where...), r.Assert...) {
+		if len(condition.Path) == 0 {
+			problems = append(problems, customRulePathMsg)
+		}
+		if _, err := regexp.Compile(condition.Pattern); err != nil {
+			problems = append(problems, fmt.Sprintf(customRulePatternErr, condition.Pattern))
+		}
+		for _, limit := range []string{condition.Min, condition.Max} {
+			if _, ok := parseQuantity(limit); len(limit) > 0 && !ok {
+				problems = append(problems, fmt.Sprintf(customRuleQuantityErr, limit))
+			}
+		}
+	}
+	return problems
+}
+
+// appliesTo returns true if the rule applies to the descriptor file
+func (r *customRule) appliesTo(file string) bool {
+	if len(r.Descriptors) == 0 {
+		return true
+	}
+	for _, pattern := range r.Descriptors {
+		if matched, _ := filepath.Match(pattern, filepath.Base(file)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMta checks the rule on the MTA descriptor
+func (r customRule) checkMta(mta *mta.MTA, root *yaml.Node, source string, strict bool) (errors []YamlValidationIssue, warnings []YamlValidationIssue) {
+	return r.check(root)
+}
+
+// checkExt checks the rule on the MTA extension descriptor
+func (r customRule) checkExt(mtaExt *mta.EXT, root *yaml.Node, source string, strict bool) (errors []YamlValidationIssue, warnings []YamlValidationIssue) {
+	return r.check(root)
+}
+
+// check checks the conditions of the rule on the modules or resources it selects in the descriptor
+func (r *customRule) check(root *yaml.Node) (errors []YamlValidationIssue, warnings []YamlValidationIssue) {
+	var issues []YamlValidationIssue
+	fieldName := modulesYamlField
+	if r.Select.Kind == resourceEntityKind {
+		fieldName = resourcesYamlField
+	}
+	for _, entityNode := range getPropContent(root, fieldName) {
+		if !r.selects(entityNode) {
+			continue
+		}
+		for _, condition := range r.Assert {
+			line, detail := condition.check(entityNode)
+			if len(detail) == 0 {
+				continue
+			}
+			if len(r.Message) > 0 {
+				detail = r.Message
+			}
+			name := ""
+			if nameNode := getPropValueByName(entityNode, nameYamlField); nameNode != nil {
+				name = nameNode.Value
+			}
+			issues = append(issues, YamlValidationIssue{Msg: fmt.Sprintf(customRuleIssueMsg, name, r.Select.Kind, r.ID, detail), Line: line})
+		}
+	}
+	if r.Severity == severityWarn {
+		return nil, issues
+	}
+	return issues, nil
+}
+
+// selects returns true if the module or resource matches the type and name patterns and meets the conditions of the selector
+func (r *customRule) selects(entityNode *yaml.Node) bool {
+	if entityNode.Kind != yaml.MappingNode {
+		return false
+	}
+	for field, pattern := range map[string]string{typeYamlField: r.Select.Type, nameYamlField: r.Select.Name} {
+		if len(pattern) == 0 {
+			continue
+		}
+		valueNode := getPropValueByName(entityNode, field)
+		if valueNode == nil {
+			return false
+		}
+		if matched, _ := filepath.Match(pattern, valueNode.Value); !matched {
+			return false
+		}
+	}
+	for _, condition := range r.Select.Where {
+		if !condition.matches(entityNode) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches returns true if the value in the path of the condition is present, literal and meets the condition
+func (c *customRuleCondition) matches(entityNode *yaml.Node) bool {
+	keyNode, valueNode := getPathNodes(entityNode, strings.Split(c.Path, "."))
+	if keyNode == nil || !isLiteralValue(valueNode) {
+		return false
+	}
+	_, detail := c.check(entityNode)
+	return len(detail) == 0
+}
+
+// check returns the line and the description of the failed condition, or an empty description if the condition is met
+func (c *customRuleCondition) check(entityNode *yaml.Node) (int, string) {
+	keyNode, valueNode := getPathNodes(entityNode, strings.Split(c.Path, "."))
+	if keyNode == nil {
+		if c.Required {
+			line := entityNode.Line
+			if nameNode := getPropValueByName(entityNode, nameYamlField); nameNode != nil {
+				line = nameNode.Line
+			}
+			return line, fmt.Sprintf(customRuleMissingMsg, c.Path)
+		}
+		return 0, ""
+	}
+	if !isLiteralValue(valueNode) {
+		return 0, ""
+	}
+	value := valueNode.Value
+	switch {
+	case c.Equals != nil && value != *c.Equals:
+		return valueNode.Line, fmt.Sprintf(customRuleEqualsMsg, c.Path, *c.Equals)
+	case c.NotEqual != nil && value == *c.NotEqual:
+		return valueNode.Line, fmt.Sprintf(customRuleNotEqualsMsg, c.Path, *c.NotEqual)
+	case len(c.OneOf) > 0 && !containsString(c.OneOf, value):
+		return valueNode.Line, fmt.Sprintf(customRuleOneOfMsg, c.Path, strings.Join(c.OneOf, ", "))
+	case len(c.NotOneOf) > 0 && containsString(c.NotOneOf, value):
+		return valueNode.Line, fmt.Sprintf(customRuleNotOneOfMsg, c.Path, strings.Join(c.NotOneOf, ", "))
+	case len(c.Pattern) > 0 && !regexp.MustCompile(c.Pattern).MatchString(value):
+		return valueNode.Line, fmt.Sprintf(customRulePatternMsg, c.Path, c.Pattern)
+	}
+	if len(c.Min) == 0 && len(c.Max) == 0 {
+		return 0, ""
+	}
+	quantity, ok := parseQuantity(value)
+	if !ok {
+		return valueNode.Line, fmt.Sprintf(customRuleNotQuantityMsg, c.Path, value)
+	}
+	if min, _ := parseQuantity(c.Min); len(c.Min) > 0 && quantity < min {
+		return valueNode.Line, fmt.Sprintf(customRuleMinMsg, c.Path, c.Min)
+	}
+	if max, _ := parseQuantity(c.Max); len(c.Max) > 0 && quantity > max {
+		return valueNode.Line, fmt.Sprintf(customRuleMaxMsg, c.Path, c.Max)
+	}
+	return 0, ""
+}
+
+// getPathNodes returns the key and the value nodes of the path of map keys, or nil if the path is not found
+func getPathNodes(node *yaml.Node, path []string) (keyNode *yaml.Node, valueNode *yaml.Node) {
+	for _, key := range path {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		if node.Kind != yaml.MappingNode {
+			return nil, nil
+		}
+		keyNode = getPropByName(node, key)
+		if keyNode == nil {
+			return nil, nil
+		}
+		node = getPropValueByName(node, key)
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return keyNode, node
+}
+
+// parseQuantity parses a number or a size with a unit, like "512M" or "1GB"
+func parseQuantity(s string) (float64, bool) {
+	match := quantityRegex.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	if len(match[2]) > 0 {
+		value *= quantityUnits[strings.ToUpper(match[2])]
+	}
+	return value, true
+}
@@ -0,0 +1,326 @@
+package validate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("Custom rules", func() {
+	const memoryRule = `
+custom-rules:
+  - id: nodejs-memory
+    select:
+      kind: module
+      type: nodejs
+    assert:
+      - path: parameters.memory
+        max: 1G
+`
+	mtaContent := []byte(`ID: mta
+_schema-version: '3.2'
+version: 0.0.1
+modules:
+  - name: small
+    type: nodejs
+    parameters:
+      memory: 512M
+  - name: big
+    type: nodejs
+    parameters:
+      memory: 2G
+  - name: placeholder
+    type: nodejs
+    parameters:
+      memory: ${default-memory}
+  - name: none
+    type: nodejs
+  - name: java
+    type: java
+    parameters:
+      memory: 4G
+  - name: invalid
+    type: nodejs
+    parameters:
+      # mta-validate-disable-next-line nodejs-memory
+      memory: 1 GiB
+`)
+
+	runCustomRules := func(config string, file string) ([]YamlValidationIssue, []YamlValidationIssue) {
+		mtaStr, err := mta.Unmarshal(mtaContent)
+		Ω(err).Should(Succeed())
+		node, err := getContentNode(mtaContent)
+		Ω(err).Should(Succeed())
+		ruleConfig, issues := parseRuleConfig([]byte(config), ruleConfigFileName)
+		Ω(issues).Should(BeEmpty())
+		return runRuleSemanticValidations(mtaStr, node, "", "paths,unusedResources,placeholders", newRuleContext(ruleConfig, node, file, true))
+	}
+
+	It("runs the custom rules on the modules they select", func() {
+		errors, warnings := runCustomRules(memoryRule, "mta.yaml")
+		Ω(errors).Should(Equal([]YamlValidationIssue{
+			{Msg: fmt.Sprintf(customRuleIssueMsg, "big", moduleEntityKind, "nodejs-memory", fmt.Sprintf(customRuleMaxMsg, "parameters.memory", "1G")), Line: 12},
+		}))
+		Ω(warnings).Should(BeEmpty())
+	})
+
+	It("reports the message of the rule with its severity", func() {
+		errors, warnings := runCustomRules(`
+custom-rules:
+  - id: memory
+    message: the memory of the Node.js modules must be between 256M and 1G
+    severity: warn
+    select:
+      kind: module
+      name: "*i*"
+      where:
+        - path: type
+          one-of: [nodejs]
+    assert:
+      - path: parameters.memory
+        required: true
+        min: 256M
+        max: 1G
+`, "mta.yaml")
+		Ω(errors).Should(BeEmpty())
+		Ω(warnings).Should(Equal([]YamlValidationIssue{
+			{Msg: fmt.Sprintf(customRuleIssueMsg, "big", moduleEntityKind, "memory", "the memory of the Node.js modules must be between 256M and 1G"), Line: 12},
+			{Msg: fmt.Sprintf(customRuleIssueMsg, "invalid", moduleEntityKind, "memory", "the memory of the Node.js modules must be between 256M and 1G"), Line: 27},
+		}))
+	})
+
+	It("reports the missing values and the values which are not sizes", func() {
+		errors, _ := runCustomRules(`
+custom-rules:
+  - id: memory
+    select:
+      kind: module
+      type: node*
+    assert:
+      - path: parameters.memory
+        required: true
+        max: 1G
+`, "mta.yaml")
+		Ω(errors).Should(Equal([]YamlValidationIssue{
+			{Msg: fmt.Sprintf(customRuleIssueMsg, "big", moduleEntityKind, "memory", fmt.Sprintf(customRuleMaxMsg, "parameters.memory", "1G")), Line: 12},
+			{Msg: fmt.Sprintf(customRuleIssueMsg, "none", moduleEntityKind, "memory", fmt.Sprintf(customRuleMissingMsg, "parameters.memory")), Line: 17},
+			{Msg: fmt.Sprintf(customRuleIssueMsg, "invalid", moduleEntityKind, "memory", fmt.Sprintf(customRuleNotQuantityMsg, "parameters.memory", "1 GiB")), Line: 27},
+		}))
+	})
+
+	It("is configured like the other rules", func() {
+		errors, warnings := runCustomRules(memoryRule+`
+rules:
+  nodejs-memory:
+    severity: warn
+`, "mta.yaml")
+		Ω(errors).Should(BeEmpty())
+		Ω(len(warnings)).Should(Equal(1))
+
+		errors, warnings = runCustomRules(memoryRule+`
+rules:
+  nodejs-memory:
+    ignore:
+      - modules.big
+`, "mta.yaml")
+		Ω(errors).Should(BeEmpty())
+		Ω(warnings).Should(BeEmpty())
+
+		errors, warnings = runCustomRules(memoryRule+`
+rules:
+  nodejs-memory:
+    severity: "off"
+`, "mta.yaml")
+		Ω(errors).Should(BeEmpty())
+		Ω(warnings).Should(BeEmpty())
+	})
+
+	It("is excluded by its whole ID", func() {
+		mtaStr, err := mta.Unmarshal(mtaContent)
+		Ω(err).Should(Succeed())
+		node, err := getContentNode(mtaContent)
+		Ω(err).Should(Succeed())
+		ruleConfig, issues := parseRuleConfig([]byte(memoryRule), ruleConfigFileName)
+		Ω(issues).Should(BeEmpty())
+
+		errors, _ := runRuleSemanticValidations(mtaStr, node, "", "paths,unusedResources,placeholders,memory",
+			newRuleContext(ruleConfig, node, "mta.yaml", true))
+		Ω(len(errors)).Should(Equal(1))
+
+		errors, _ = runRuleSemanticValidations(mtaStr, node, "", "paths,unusedResources,placeholders, nodejs-memory",
+			newRuleContext(ruleConfig, node, "mta.yaml", true))
+		Ω(errors).Should(BeEmpty())
+	})
+
+	It("does not select the modules or resources without the values of the conditions", func() {
+		var rule customRule
+		Ω(yaml.Unmarshal([]byte(`
+id: no-trial-plans-in-prod
+select:
+  kind: resource
+  where:
+    - path: parameters.env
+      equals: prod
+assert:
+  - path: parameters.service-plan
+    not-equals: trial
+`), &rule)).Should(Succeed())
+		node, err := getContentNode([]byte(`resources:
+  - name: no-env
+    parameters:
+      service-plan: trial
+  - name: dev
+    parameters:
+      env: dev
+      service-plan: trial
+  - name: env-placeholder
+    parameters:
+      env: ${env}
+      service-plan: trial
+  - name: prod
+    parameters:
+      env: prod
+      service-plan: trial
+`))
+		Ω(err).Should(Succeed())
+		errors, _ := rule.check(node)
+		Ω(errors).Should(Equal([]YamlValidationIssue{
+			{Msg: fmt.Sprintf(customRuleIssueMsg, "prod", resourceEntityKind, "no-trial-plans-in-prod",
+				fmt.Sprintf(customRuleNotEqualsMsg, "parameters.service-plan", "trial")), Line: 16},
+		}))
+	})
+
+	It("only runs on the descriptors the rule applies to", func() {
+		errors, _ := runCustomRules(memoryRule+`    descriptors: ["*.mtaext"]
+`, "mta.yaml")
+		Ω(errors).Should(BeEmpty())
+	})
+
+	DescribeTable("conditions", func(condition string, value string, expected string) {
+		var rule customRule
+		Ω(yaml.Unmarshal([]byte(condition), &rule.Assert)).Should(Succeed())
+		rule.ID, rule.Select.Kind = "rule", resourceEntityKind
+		node, err := getContentNode([]byte("resources:\n  - name: r\n    parameters:\n      value: " + value + "\n"))
+		Ω(err).Should(Succeed())
+		errors, _ := rule.check(node)
+		if len(expected) == 0 {
+			Ω(errors).Should(BeEmpty())
+		} else {
+			Ω(errors).Should(Equal([]YamlValidationIssue{
+				{Msg: fmt.Sprintf(customRuleIssueMsg, "r", resourceEntityKind, "rule", expected), Line: 4}}))
+		}
+	},
+		Entry("equals", "- {path: parameters.value, equals: a}", "a", ""),
+		Entry("not equals", "- {path: parameters.value, equals: a}", "b", fmt.Sprintf(customRuleEqualsMsg, "parameters.value", "a")),
+		Entry("not-equals", "- {path: parameters.value, not-equals: trial}", "lite", ""),
+		Entry("equal to not-equals", "- {path: parameters.value, not-equals: trial}", "trial", fmt.Sprintf(customRuleNotEqualsMsg, "parameters.value", "trial")),
+		Entry("one-of", "- {path: parameters.value, one-of: [a, b]}", "c", fmt.Sprintf(customRuleOneOfMsg, "parameters.value", "a, b")),
+		Entry("not-one-of", "- {path: parameters.value, not-one-of: [a, b]}", "b", fmt.Sprintf(customRuleNotOneOfMsg, "parameters.value", "a, b")),
+		Entry("pattern", "- {path: parameters.value, pattern: '^[a-z]+$'}", "abc", ""),
+		Entry("not matching the pattern", "- {path: parameters.value, pattern: '^[a-z]+$'}", "a1", fmt.Sprintf(customRulePatternMsg, "parameters.value", "^[a-z]+$")),
+		Entry("min", "- {path: parameters.value, min: 2}", "1", fmt.Sprintf(customRuleMinMsg, "parameters.value", "2")),
+		Entry("size with a unit", "- {path: parameters.value, max: 1G}", "1024MB", ""),
+		Entry("number", "- {path: parameters.value, equals: '1'}", "1", ""),
+		Entry("variable", "- {path: parameters.value, equals: a}", "~{a/b}", ""),
+	)
+
+	DescribeTable("parseQuantity", func(value string, expected float64, expectedOk bool) {
+		quantity, ok := parseQuantity(value)
+		Ω(ok).Should(Equal(expectedOk))
+		Ω(quantity).Should(Equal(expected))
+	},
+		Entry("number", "100", float64(100), true),
+		Entry("decimal number", "1.5", 1.5, true),
+		Entry("kilobytes", "2K", float64(2048), true),
+		Entry("megabytes", "512MB", float64(512*1024*1024), true),
+		Entry("gigabytes in lower case", "1g", float64(1024*1024*1024), true),
+		Entry("unknown unit", "1X", float64(0), false),
+		Entry("empty", "", float64(0), false),
+	)
+
+	It("reports the invalid custom rules", func() {
+		configPath := ruleConfigFileName
+		_, issues := parseRuleConfig([]byte(`
+custom-rules:
+  - select:
+      kind: module
+  - id: paths
+    select:
+      kind: module
+  - id: invalid
+    severity: "off"
+    select:
+      kind: service
+    assert:
+      - pattern: "("
+        max: big
+`), configPath)
+		Ω(issues).Should(Equal([]YamlValidationIssue{
+			{Msg: fmt.Sprintf(customRuleIDMsg, 1, configPath)},
+			{Msg: fmt.Sprintf(customRuleDuplicateMsg, "paths", configPath)},
+			{Msg: fmt.Sprintf(customRuleInvalidMsg, "invalid", configPath, fmt.Sprintf(customRuleKindMsg, "service"))},
+			{Msg: fmt.Sprintf(customRuleInvalidMsg, "invalid", configPath, fmt.Sprintf(customRuleSeverityMsg, "off"))},
+			{Msg: fmt.Sprintf(customRuleInvalidMsg, "invalid", configPath, customRulePathMsg)},
+			{Msg: fmt.Sprintf(customRuleInvalidMsg, "invalid", configPath, fmt.Sprintf(customRulePatternErr, "("))},
+			{Msg: fmt.Sprintf(customRuleInvalidMsg, "invalid", configPath, fmt.Sprintf(customRuleQuantityErr, "big"))},
+		}))
+	})
+
+	Describe("in the MTA extensions", func() {
+		var dir string
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "custom")
+			Ω(err).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(dir, ruleConfigFileName), []byte(`
+custom-rules:
+  - id: no-trial-plans
+    descriptors: ["*prod*.mtaext"]
+    select:
+      kind: resource
+    assert:
+      - path: parameters.service-plan
+        required: true
+        not-equals: trial
+`), 0644)).Should(Succeed())
+		})
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		ext := []byte(`_schema-version: '3.2'
+ID: mta.ext
+extends: mta
+resources:
+  - name: db
+    parameters:
+      service-plan: trial
+  - name: uaa
+    parameters:
+      service-plan: application
+`)
+
+		It("runs the custom rules on the extensions they apply to", func() {
+			Ω(ioutil.WriteFile(filepath.Join(dir, "prod.mtaext"), ext, 0644)).Should(Succeed())
+			_, err := Mtaext(dir, filepath.Join(dir, "prod.mtaext"), true, true, true, "")
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("line 7: " + fmt.Sprintf(customRuleIssueMsg, "db", resourceEntityKind,
+				"no-trial-plans", fmt.Sprintf(customRuleNotEqualsMsg, "parameters.service-plan", "trial"))))
+			Ω(err.Error()).ShouldNot(ContainSubstring(`"uaa"`))
+		})
+
+		It("does not run the custom rules on the other extensions", func() {
+			Ω(ioutil.WriteFile(filepath.Join(dir, "dev.mtaext"), ext, 0644)).Should(Succeed())
+			_, err := Mtaext(dir, filepath.Join(dir, "dev.mtaext"), true, true, true, "")
+			Ω(err).Should(Succeed())
+		})
+	})
+})
@@ -63,14 +63,19 @@ func validateExt(yamlContent []byte, projectPath string, extFileName string,
 		warnIssues = convertError(err)
 	}
 
-	if validateSchema {
-		errs, warns := validateExtSchema(mtaExt, extNode, strict)
+	config, configIssues := loadRuleConfig(projectPath)
+	errIssues = append(errIssues, configIssues...)
+	rules := newRuleContext(config, extNode, extFileName, strict)
+
+	if validateSchema && rules.isEnabled(schemaValidation) {
+		errs, warns := validateExtSchema(mtaExt, extNode, rules.isStrict(schemaValidation))
+		errs, warns = rules.apply(schemaValidation, errs, warns)
 		errIssues = append(errIssues, errs...)
 		warnIssues = append(warnIssues, warns...)
 	}
 
 	if validateSemantic {
-		errs, warns := runExtSemanticValidations(mtaExt, extNode, projectPath, exclude, strict)
+		errs, warns := runRuleExtSemanticValidations(mtaExt, extNode, projectPath, exclude, rules)
 		errIssues = append(errIssues, errs...)
 		warnIssues = append(warnIssues, warns...)
 	}
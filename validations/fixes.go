@@ -0,0 +1,368 @@
+package validate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	deprecatedOptFixTitle   = `replace the "%s" build parameter with the commands of the "custom" builder`
+	missingConfigsFixTitle  = `add the missing build configurations of the "%s" module`
+	noSourceFixTitle        = `convert the "no-source" build parameter to a boolean`
+	missingCommandsFixTitle = `add an empty "commands" property to the "custom" builder`
+	appliedFixMsg           = `line %d: %s`
+	fixReadFailedMsg        = `could not read the "%s" file; the fix failed`
+	fixWriteFailedMsg       = `could not write the "%s" file; the fix failed`
+
+	// The build result of HTML5 modules which are deployed to the HTML5 repository
+	html5BuildResult = "dist"
+)
+
+// YamlTextEdit - a change of the text of the YAML file. The positions are 1-based, like the positions of the YAML nodes;
+// the text from the start position up to the end position, which is not included, is replaced by the new text
+type YamlTextEdit struct {
+	Line      int
+	Column    int
+	EndLine   int
+	EndColumn int
+	NewText   string
+}
+
+// YamlFix - a mechanical fix of a validation issue; its edits are applied together
+type YamlFix struct {
+	// Title - the description of the fix
+	Title string
+	Edits []YamlTextEdit
+}
+
+func newFix(title string, edits ...YamlTextEdit) *YamlFix {
+	return &YamlFix{Title: title, Edits: edits}
+}
+
+// getNodeEnd returns the position after the last character of the node. The position is only known for
+// single-line scalars and block collections which end with them, since the YAML nodes do not keep their end position.
+// The next node is the node which follows the node in the document, or nil if it is not known. A scalar which spans
+// several lines is folded into a value with spaces, so a value with spaces is only known to be on a single line
+// when the next node starts on the same line or on the line after it.
+func getNodeEnd(node *yaml.Node, next *yaml.Node) (line int, column int, ok bool) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if len(node.Value) == 0 || strings.Contains(node.Value, "\n") || node.Style&yaml.TaggedStyle != 0 {
+			return 0, 0, false
+		}
+		if strings.ContainsAny(node.Value, " \t") && (next == nil || next.Line > node.Line+1) {
+			return 0, 0, false
+		}
+		length := utf8.RuneCountInString(node.Value)
+		switch {
+		case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+			return 0, 0, false
+		case node.Style&yaml.SingleQuotedStyle != 0:
+			length = utf8.RuneCountInString(strings.Replace(node.Value, "'", "''", -1)) + 2
+		case node.Style&yaml.DoubleQuotedStyle != 0:
+			if strings.ContainsAny(node.Value, `"\`) {
+				return 0, 0, false
+			}
+			length += 2
+		}
+		return node.Line, node.Column + length, true
+	case yaml.MappingNode, yaml.SequenceNode:
+		if node.Style&yaml.FlowStyle != 0 || len(node.Content) == 0 {
+			return 0, 0, false
+		}
+		return getNodeEnd(node.Content[len(node.Content)-1], next)
+	}
+	return 0, 0, false
+}
+
+// getNextNode returns the node which follows the node in the parent node, or nil if it is the last one;
+// in a mapping, the node which follows a value is the next key
+func getNextNode(parentNode *yaml.Node, node *yaml.Node) *yaml.Node {
+	for i, child := range parentNode.Content {
+		if child == node && i+1 < len(parentNode.Content) {
+			return parentNode.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// getReplaceEdit returns the edit which replaces the text from the start node up to the end of the end node;
+// the next node is the node which follows the end node in the document, or nil if it is not known
+func getReplaceEdit(startNode, endNode, next *yaml.Node, newText string) (YamlTextEdit, bool) {
+	line, column, ok := getNodeEnd(endNode, next)
+	if !ok {
+		return YamlTextEdit{}, false
+	}
+	return YamlTextEdit{Line: startNode.Line, Column: startNode.Column, EndLine: line, EndColumn: column, NewText: newText}, true
+}
+
+// getInsertBeforeKeyEdit returns the edit which inserts lines before the line of the key and its comments,
+// with the indentation of the key. The key must be the first node in its line.
+func getInsertBeforeKeyEdit(keyNode *yaml.Node, lines ...string) YamlTextEdit {
+	line := keyNode.Line
+	if len(keyNode.HeadComment) > 0 {
+		line -= strings.Count(keyNode.HeadComment, "\n") + 1
+	}
+	return YamlTextEdit{Line: line, Column: 1, EndLine: line, EndColumn: 1,
+		NewText: indentLines(lines, keyNode.Column-1)}
+}
+
+// getInsertAfterEntryEdit returns the edit which inserts lines after the line where the value of the key ends,
+// with the indentation of the key; the next node is the node which follows the value in the document, or nil if it is not known
+func getInsertAfterEntryEdit(keyNode, valueNode, next *yaml.Node, lines ...string) (YamlTextEdit, bool) {
+	line, _, ok := getNodeEnd(valueNode, next)
+	if !ok {
+		return YamlTextEdit{}, false
+	}
+	return YamlTextEdit{Line: line + 1, Column: 1, EndLine: line + 1, EndColumn: 1,
+		NewText: indentLines(lines, keyNode.Column-1)}, true
+}
+
+func indentLines(lines []string, indent int) string {
+	var text strings.Builder
+	for _, line := range lines {
+		text.WriteString(strings.Repeat(" ", indent))
+		text.WriteString(line)
+		text.WriteString("\n")
+	}
+	return text.String()
+}
+
+// getScalarText returns the YAML text of a string scalar, which is quoted when needed
+func getScalarText(value string) string {
+	text, err := yaml.Marshal(value)
+	if err != nil {
+		return value
+	}
+	return strings.TrimSuffix(string(text), "\n")
+}
+
+// getDeprecatedOptFix returns the fix which replaces the deprecated build option with the commands
+// of the "custom" builder, when it can be converted
+func getDeprecatedOptFix(buildParamsNode *yaml.Node, optFieldName string) *YamlFix {
+	if buildParamsNode.Kind == yaml.AliasNode {
+		buildParamsNode = buildParamsNode.Alias
+	}
+	commands, reason := getOptCommands(buildParamsNode, optFieldName)
+	if len(reason) > 0 {
+		return nil
+	}
+	builderNode := getPropValueByName(buildParamsNode, builderYamlField)
+	builderEdit, ok := getReplaceEdit(builderNode, builderNode, getNextNode(buildParamsNode, builderNode), customBuilder)
+	if !ok {
+		return nil
+	}
+	optKeyNode := getPropByName(buildParamsNode, optFieldName)
+	// The option is replaced from its key, so the first line is not indented
+	text := commandsYamlField + ":"
+	for _, command := range commands {
+		text += "\n" + strings.Repeat(" ", optKeyNode.Column-1) + "  - " + getScalarText(command)
+	}
+	optValueNode := getPropValueByName(buildParamsNode, optFieldName)
+	optEdit, ok := getReplaceEdit(optKeyNode, optValueNode, getNextNode(buildParamsNode, optValueNode), text)
+	if !ok {
+		return nil
+	}
+	return newFix(fmt.Sprintf(deprecatedOptFixTitle, optFieldName), builderEdit, optEdit)
+}
+
+// getMissingConfigsFix returns the fix which adds the missing "supported-platforms" and "build-result"
+// build configurations of an HTML5 module
+func getMissingConfigsFix(moduleName string, moduleNode *yaml.Node, supportedPlatformsDefined, buildResultDefined bool) *YamlFix {
+	var lines []string
+	if !supportedPlatformsDefined {
+		lines = append(lines, supportedPlatformsYamlField+": []")
+	}
+	if !buildResultDefined {
+		lines = append(lines, buildResultYamlField+": "+html5BuildResult)
+	}
+	title := fmt.Sprintf(missingConfigsFixTitle, moduleName)
+
+	buildParamsNode := getPropValueByName(moduleNode, buildParametersYamlField)
+	if buildParamsNode != nil {
+		if buildParamsNode.Kind != yaml.MappingNode || buildParamsNode.Style&yaml.FlowStyle != 0 || len(buildParamsNode.Content) == 0 {
+			return nil
+		}
+		return newFix(title, getInsertBeforeKeyEdit(buildParamsNode.Content[0], lines...))
+	}
+
+	// The first key of the module is in the line of the sequence item, so the build parameters are added before the second key
+	if moduleNode.Style&yaml.FlowStyle != 0 || len(moduleNode.Content) < 4 || moduleNode.Content[2].Line == moduleNode.Content[0].Line {
+		return nil
+	}
+	for i, line := range lines {
+		lines[i] = "  " + line
+	}
+	lines = append([]string{buildParametersYamlField + ":"}, lines...)
+	return newFix(title, getInsertBeforeKeyEdit(moduleNode.Content[2], lines...))
+}
+
+// getNoSourceFix returns the fix which converts a "no-source" string value of "true" or "false" to a boolean
+func getNoSourceFix(noSourceNode *yaml.Node) *YamlFix {
+	value := strings.ToLower(noSourceNode.Value)
+	if noSourceNode.Kind != yaml.ScalarNode || value != "true" && value != "false" {
+		return nil
+	}
+	// the value is a single word, so it is on a single line
+	edit, ok := getReplaceEdit(noSourceNode, noSourceNode, nil, value)
+	if !ok {
+		return nil
+	}
+	return newFix(noSourceFixTitle, edit)
+}
+
+// getMissingCommandsFix returns the fix which adds an empty "commands" property after the "builder" property
+func getMissingCommandsFix(builderParamsNode *yaml.Node) *YamlFix {
+	builderKeyNode := getPropByName(builderParamsNode, builderYamlField)
+	if builderKeyNode == nil {
+		return nil
+	}
+	builderNode := getPropValueByName(builderParamsNode, builderYamlField)
+	edit, ok := getInsertAfterEntryEdit(builderKeyNode, builderNode, getNextNode(builderParamsNode, builderNode), commandsYamlField+": []")
+	if !ok {
+		return nil
+	}
+	return newFix(missingCommandsFixTitle, edit)
+}
+
+// splitLines splits the content to lines, which keep their line breaks
+func splitLines(content string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '\r':
+			if i+1 < len(content) && content[i+1] == '\n' {
+				i++
+			}
+		case '\n':
+		default:
+			continue
+		}
+		lines = append(lines, content[start:i+1])
+		start = i + 1
+	}
+	if start < len(content) {
+		lines = append(lines, content[start:])
+	}
+	return lines
+}
+
+// getOffset returns the offset in the content of the 1-based position, or false if the position is not in the content
+func getOffset(lines []string, line, column int) (int, bool) {
+	offset := 0
+	if line < 1 || line > len(lines)+1 || column < 1 {
+		return 0, false
+	}
+	for _, l := range lines[:line-1] {
+		offset += len(l)
+	}
+	if line == len(lines)+1 {
+		return offset, column == 1
+	}
+	text := strings.TrimRight(lines[line-1], "\r\n")
+	for i := range text {
+		if column == 1 {
+			return offset + i, true
+		}
+		column--
+	}
+	return offset + len(text), column == 1
+}
+
+type textRange struct {
+	start   int
+	end     int
+	newText string
+}
+
+// applyFixes applies the fixes of the issues to the content. A fix is skipped if its edits are invalid or
+// overlap the edits of a previous fix. It returns the fixed content and the issues whose fixes were applied.
+func applyFixes(content []byte, issues []YamlValidationIssue) ([]byte, []YamlValidationIssue) {
+	text := string(content)
+	lines := splitLines(text)
+	var ranges []textRange
+	var fixed []YamlValidationIssue
+
+	for _, issue := range issues {
+		if issue.Fix == nil {
+			continue
+		}
+		var fixRanges []textRange
+		valid := true
+		for _, edit := range issue.Fix.Edits {
+			start, ok1 := getOffset(lines, edit.Line, edit.Column)
+			end, ok2 := getOffset(lines, edit.EndLine, edit.EndColumn)
+			if !ok1 || !ok2 || start > end || overlaps(ranges, start, end) || overlaps(fixRanges, start, end) {
+				valid = false
+				break
+			}
+			newText := edit.NewText
+			// Lines inserted after the last line, when the content does not end with a line break
+			if start == len(text) && len(text) > 0 && !strings.HasSuffix(text, "\n") && !strings.HasSuffix(text, "\r") {
+				newText = "\n" + newText
+			}
+			fixRanges = append(fixRanges, textRange{start, end, newText})
+		}
+		if valid {
+			ranges = append(ranges, fixRanges...)
+			fixed = append(fixed, issue)
+		}
+	}
+
+	// The edits are applied from the end, so the offsets of the other edits do not change
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].start > ranges[j].start
+	})
+	for _, r := range ranges {
+		text = text[:r.start] + r.newText + text[r.end:]
+	}
+	return []byte(text), fixed
+}
+
+// overlaps returns true if the range overlaps one of the ranges; insertions in the same position overlap
+func overlaps(ranges []textRange, start, end int) bool {
+	for _, r := range ranges {
+		if start < r.end && r.start < end || start == r.start {
+			return true
+		}
+	}
+	return false
+}
+
+// FixMtaYaml applies the fixes of the issues found by the validations to the MTA.yaml file. The edits only change the
+// fixed parts of the file, so its comments and formatting are preserved. It returns the report of the applied fixes.
+func FixMtaYaml(projectPath, mtaFilename, schemaVersion string,
+	validateSchema, validateSemantic, strict bool, exclude string) ([]string, error) {
+	mtaPath := filepath.Join(projectPath, mtaFilename)
+	content, err := ioutil.ReadFile(mtaPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, fixReadFailedMsg, mtaPath)
+	}
+	yamlContent := []byte(strings.Replace(string(content), "\r\n", "\r", -1))
+	errIssues, warnIssues := validateWithSchemaVersion(yamlContent, projectPath, mtaFilename, schemaVersion,
+		validateSchema, validateSemantic, strict, exclude)
+	issues := append(errIssues, warnIssues...)
+	issues.Sort()
+
+	fixedContent, fixed := applyFixes(content, issues)
+	if len(fixed) == 0 {
+		return nil, nil
+	}
+	err = ioutil.WriteFile(mtaPath, fixedContent, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, fixWriteFailedMsg, mtaPath)
+	}
+	var report []string
+	for _, issue := range fixed {
+		report = append(report, fmt.Sprintf(appliedFixMsg, issue.Line, issue.Fix.Title))
+	}
+	return report, nil
+}
@@ -0,0 +1,238 @@
+package validate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+// getFixedContent returns the content with the fixes of the issues found by the semantic check applied
+func getFixedContent(content string, check checkSemantic) (string, []YamlValidationIssue) {
+	mtaContent := []byte(content)
+	mtaStr, err := mta.Unmarshal(mtaContent)
+	Ω(err).Should(Succeed())
+	node, err := getContentNode(mtaContent)
+	Ω(err).Should(Succeed())
+	errors, warnings := check(mtaStr, node, "", true)
+	fixed, fixedIssues := applyFixes(mtaContent, append(errors, warnings...))
+	return string(fixed), fixedIssues
+}
+
+var _ = Describe("Fixes", func() {
+	It("replaces the deprecated build options with the commands of the custom builder, preserving the comments", func() {
+		fixed, issues := getFixedContent(`ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+  - name: ui5app1
+    type: html5
+    build-parameters:
+      builder: npm # the builder
+      # install without the optional packages
+      npm-opts:
+        no-optional: true
+      timeout: 15m
+  - name: java
+    type: java
+    build-parameters:
+      builder: maven
+      maven-opts:
+        nested:
+          a: b
+`, checkDeprecatedOpts)
+		Ω(fixed).Should(Equal(`ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+  - name: ui5app1
+    type: html5
+    build-parameters:
+      builder: custom # the builder
+      # install without the optional packages
+      commands:
+        - npm install --production --no-optional
+      timeout: 15m
+  - name: java
+    type: java
+    build-parameters:
+      builder: maven
+      maven-opts:
+        nested:
+          a: b
+`))
+		Ω(len(issues)).Should(Equal(1))
+		Ω(issues[0].Line).Should(Equal(11))
+	})
+
+	It("adds the missing build configurations of the HTML5 modules", func() {
+		fixed, issues := getFixedContent(`ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+  - name: ui5app1
+    type: html5
+    path: ui5app1
+  - name: ui5app2
+    type: html5
+    build-parameters:
+      # the result
+      # of the build
+      build-result: out
+parameters:
+  deploy_mode: html5-repo
+`, checkDeployerConstraints)
+		Ω(fixed).Should(Equal(`ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+  - name: ui5app1
+    build-parameters:
+      supported-platforms: []
+      build-result: dist
+    type: html5
+    path: ui5app1
+  - name: ui5app2
+    type: html5
+    build-parameters:
+      supported-platforms: []
+      # the result
+      # of the build
+      build-result: out
+parameters:
+  deploy_mode: html5-repo
+`))
+		Ω(len(issues)).Should(Equal(2))
+	})
+
+	It("converts the no-source string values to booleans", func() {
+		fixed, issues := getFixedContent(`ID: mta
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+  - name: a
+    type: java
+    build-parameters:
+      no-source: "True" # no sources
+  - name: b
+    type: java
+    build-parameters:
+      no-source: "maybe"
+`, ifModulePathExists)
+		Ω(fixed).Should(ContainSubstring(`      no-source: true # no sources
+`))
+		Ω(fixed).Should(ContainSubstring(`      no-source: "maybe"
+`))
+		Ω(len(issues)).Should(Equal(1))
+	})
+
+	It("adds the missing commands of the custom builders", func() {
+		fixed, issues := getFixedContent(`ID: mta
+_schema-version: '3.2'
+version: 0.0.1
+
+build-parameters:
+  before-all:
+    - builder: custom
+modules:
+  - name: a
+    type: java
+    build-parameters:
+      builder: custom`, checkBuildersSemantic)
+		Ω(fixed).Should(Equal(`ID: mta
+_schema-version: '3.2'
+version: 0.0.1
+
+build-parameters:
+  before-all:
+    - builder: custom
+      commands: []
+modules:
+  - name: a
+    type: java
+    build-parameters:
+      builder: custom
+      commands: []
+`))
+		Ω(len(issues)).Should(Equal(2))
+	})
+
+	It("skips the fixes which overlap the edits of a previous fix", func() {
+		edit := YamlTextEdit{Line: 1, Column: 1, EndLine: 1, EndColumn: 3, NewText: "x"}
+		overlapping := YamlTextEdit{Line: 1, Column: 2, EndLine: 1, EndColumn: 4, NewText: "y"}
+		invalid := YamlTextEdit{Line: 5, Column: 1, EndLine: 5, EndColumn: 1, NewText: "z"}
+		fixed, issues := applyFixes([]byte("abcd\r\nef\r\n"), []YamlValidationIssue{
+			{Msg: "first", Line: 1, Fix: newFix("first", edit)},
+			{Msg: "second", Line: 1, Fix: newFix("second", overlapping)},
+			{Msg: "third", Line: 2},
+			{Msg: "fourth", Line: 5, Fix: newFix("fourth", invalid)},
+			{Msg: "fifth", Line: 2, Fix: newFix("fifth", YamlTextEdit{Line: 2, Column: 3, EndLine: 2, EndColumn: 3, NewText: "g"})},
+		})
+		Ω(string(fixed)).Should(Equal("xcd\r\nefg\r\n"))
+		Ω(len(issues)).Should(Equal(2))
+		Ω(issues[0].Msg).Should(Equal("first"))
+		Ω(issues[1].Msg).Should(Equal("fifth"))
+	})
+
+	Describe("FixMtaYaml", func() {
+		var dir string
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "fix")
+			Ω(err).Should(Succeed())
+		})
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("applies the fixes to the file and returns the report", func() {
+			Ω(ioutil.WriteFile(filepath.Join(dir, "mta.yaml"), []byte(`ID: mta
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+  - name: a
+    type: java
+    build-parameters:
+      no-source: 'false'
+      builder: custom
+`), 0644)).Should(Succeed())
+			report, err := FixMtaYaml(dir, "mta.yaml", "", true, true, true, "")
+			Ω(err).Should(Succeed())
+			Ω(report).Should(Equal([]string{
+				"line 9: " + noSourceFixTitle,
+				"line 10: " + missingCommandsFixTitle,
+			}))
+			content, err := ioutil.ReadFile(filepath.Join(dir, "mta.yaml"))
+			Ω(err).Should(Succeed())
+			Ω(string(content)).Should(ContainSubstring(`      no-source: false
+      builder: custom
+      commands: []
+`))
+		})
+
+		It("does not change the file when there is nothing to fix", func() {
+			Ω(ioutil.WriteFile(filepath.Join(dir, "mta.yaml"), []byte(`ID: mta
+_schema-version: '3.2'
+version: 0.0.1
+`), 0644)).Should(Succeed())
+			report, err := FixMtaYaml(dir, "mta.yaml", "", true, true, true, "")
+			Ω(err).Should(Succeed())
+			Ω(report).Should(BeNil())
+		})
+
+		It("fails when the file does not exist", func() {
+			_, err := FixMtaYaml(dir, "mta1.yaml", "", true, true, true, "")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
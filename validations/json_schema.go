@@ -0,0 +1,315 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	jsonSchemaParseFailedMsg = `could not parse the "%s" MTA schema; the JSON Schema generation failed`
+	jsonSchemaRuleMsg        = `invalid .yaml file schema: the rule in line %d must be a map`
+	jsonSchemaSequenceMsg    = `invalid .yaml file schema: the sequence node in line %d must have exactly one item`
+
+	jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+	// The comment of the generated JSON Schema; it points to the schema it is generated from
+	jsonSchemaComment = `Generated from the %s MTA schema; do not edit`
+	// The key of the rule which applies to all the keys of a mapping
+	anyKeyRule = "="
+)
+
+// jsonSchema is a JSON Schema node; its fields are written in the order of their declaration
+type jsonSchema struct {
+	Schema               string         `json:"$schema,omitempty"`
+	ID                   string         `json:"$id,omitempty"`
+	Title                string         `json:"title,omitempty"`
+	Comment              string         `json:"$comment,omitempty"`
+	Description          string         `json:"description,omitempty"`
+	Ref                  string         `json:"$ref,omitempty"`
+	Type                 string         `json:"type,omitempty"`
+	Pattern              string         `json:"pattern,omitempty"`
+	Enum                 []string       `json:"enum,omitempty"`
+	Const                interface{}    `json:"const,omitempty"`
+	Default              interface{}    `json:"default,omitempty"`
+	OneOf                []*jsonSchema  `json:"oneOf,omitempty"`
+	AnyOf                []*jsonSchema  `json:"anyOf,omitempty"`
+	Required             []string       `json:"required,omitempty"`
+	Properties           *jsonSchemaMap `json:"properties,omitempty"`
+	AdditionalProperties interface{}    `json:"additionalProperties,omitempty"`
+	MinItems             int            `json:"minItems,omitempty"`
+	UniqueItems          bool           `json:"uniqueItems,omitempty"`
+	Items                *jsonSchema    `json:"items,omitempty"`
+	Definitions          *jsonSchemaMap `json:"definitions,omitempty"`
+}
+
+// jsonSchemaMap is a map of JSON Schema nodes which keeps the order of its keys
+type jsonSchemaMap struct {
+	keys   []string
+	values map[string]*jsonSchema
+}
+
+func newJSONSchemaMap() *jsonSchemaMap {
+	return &jsonSchemaMap{values: make(map[string]*jsonSchema)}
+}
+
+func (m *jsonSchemaMap) set(key string, value *jsonSchema) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *jsonSchemaMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		if err := marshalJSON(&buf, key, ""); err != nil {
+			return nil, err
+		}
+		buf.WriteString(":")
+		if err := marshalJSON(&buf, m.values[key], ""); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+// marshalJSON writes the value as JSON to the buffer, without escaping the HTML characters of the descriptions
+func marshalJSON(buf *bytes.Buffer, v interface{}, indent string) error {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	// The encoder ends the value with a new line
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
+
+// BuildJSONSchema converts an MTA schema, defined in the kwalify format, to a JSON Schema,
+// so the IDE validations and the command line validations agree.
+// The descriptions are taken from the "desc" fields of the rules, or from the comments of their keys,
+// and the anchored rules are converted to definitions.
+func BuildJSONSchema(schemaText []byte, source string) ([]byte, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(schemaText, &document)
+	if err != nil {
+		return nil, errors.Wrapf(err, jsonSchemaParseFailedMsg, source)
+	}
+	if len(document.Content) == 0 {
+		return nil, errors.Errorf(jsonSchemaParseFailedMsg, source)
+	}
+
+	definitions := newJSONSchemaMap()
+	schema, err := buildJSONSchemaRule(document.Content[0], definitions)
+	if err != nil {
+		return nil, err
+	}
+	// The rules of the "definitions" node are referenced by "$ref"
+	if definitionsNode := getPropValueByName(document.Content[0], "definitions"); definitionsNode != nil {
+		for i := 0; i+1 < len(definitionsNode.Content); i += 2 {
+			definition, err := buildJSONSchemaRule(definitionsNode.Content[i+1], definitions)
+			if err != nil {
+				return nil, err
+			}
+			definitions.set(definitionsNode.Content[i].Value, definition)
+		}
+	}
+	schema.Schema = jsonSchemaDraft
+	schema.Comment = fmt.Sprintf(jsonSchemaComment, source)
+	if nameNode := getPropValueByName(document.Content[0], "name"); nameNode != nil {
+		schema.Title = nameNode.Value
+	}
+	if idNode := getPropValueByName(document.Content[0], "id"); idNode != nil {
+		schema.ID = idNode.Value
+	}
+	if len(definitions.keys) > 0 {
+		schema.Definitions = definitions
+	}
+
+	var buf bytes.Buffer
+	if err = marshalJSON(&buf, schema, "  "); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// buildJSONSchemaRule converts a kwalify rule to a JSON Schema node; anchored rules are added to the definitions
+// and referenced by their anchor
+func buildJSONSchemaRule(rule *yaml.Node, definitions *jsonSchemaMap) (*jsonSchema, error) {
+	if rule.Kind == yaml.AliasNode {
+		return &jsonSchema{Ref: "#/definitions/" + rule.Alias.Anchor}, nil
+	}
+	if len(rule.Anchor) > 0 {
+		anchor := rule.Anchor
+		rule.Anchor = ""
+		definition, err := buildJSONSchemaRule(rule, definitions)
+		rule.Anchor = anchor
+		if err != nil {
+			return nil, err
+		}
+		definitions.set(anchor, definition)
+		return &jsonSchema{Ref: "#/definitions/" + anchor}, nil
+	}
+
+	// A rule without a value is an optional string
+	if rule.Kind == yaml.ScalarNode && rule.ShortTag() == "!!null" {
+		return &jsonSchema{Type: "string"}, nil
+	}
+	if rule.Kind != yaml.MappingNode {
+		return nil, errors.Errorf(jsonSchemaRuleMsg, rule.Line)
+	}
+
+	schema := &jsonSchema{}
+	if descNode := getPropValueByName(rule, "desc"); descNode != nil {
+		schema.Description = descNode.Value
+	}
+	if refNode := getPropValueByName(rule, "$ref"); refNode != nil {
+		schema.Ref = "#/definitions/" + strings.TrimPrefix(refNode.Value, "#/definitions/")
+		return schema, nil
+	}
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		alternativesNode := getPropValueByName(rule, keyword)
+		if alternativesNode == nil {
+			continue
+		}
+		var alternatives []*jsonSchema
+		for _, alternativeNode := range alternativesNode.Content {
+			alternative, err := buildJSONSchemaRule(alternativeNode, definitions)
+			if err != nil {
+				return nil, err
+			}
+			alternatives = append(alternatives, alternative)
+		}
+		if keyword == "oneOf" {
+			schema.OneOf = alternatives
+		} else {
+			schema.AnyOf = alternatives
+		}
+		return schema, nil
+	}
+	ruleType := "str"
+	if typeNode := getPropValueByName(rule, "type"); typeNode != nil {
+		ruleType = typeNode.Value
+	}
+	switch ruleType {
+	case "map":
+		schema.Type = "object"
+		if err := buildJSONSchemaMapping(schema, getPropValueByName(rule, "mapping"), definitions); err != nil {
+			return nil, err
+		}
+		if additionalNode := getPropValueByName(rule, "additionalProperties"); additionalNode != nil && additionalNode.Value == "false" {
+			schema.AdditionalProperties = false
+		}
+	case "seq":
+		schema.Type = "array"
+		sequenceNode := getPropValueByName(rule, "sequence")
+		if sequenceNode == nil || sequenceNode.Kind != yaml.SequenceNode || len(sequenceNode.Content) != 1 {
+			return nil, errors.Errorf(jsonSchemaSequenceMsg, rule.Line)
+		}
+		items, err := buildJSONSchemaRule(sequenceNode.Content[0], definitions)
+		if err != nil {
+			return nil, err
+		}
+		schema.Items = items
+		if minItemsNode := getPropValueByName(rule, "minItems"); minItemsNode != nil {
+			if err := minItemsNode.Decode(&schema.MinItems); err != nil {
+				return nil, err
+			}
+		}
+		if uniqueItemsNode := getPropValueByName(rule, "uniqueItems"); uniqueItemsNode != nil {
+			if err := uniqueItemsNode.Decode(&schema.UniqueItems); err != nil {
+				return nil, err
+			}
+		}
+	case "bool":
+		schema.Type = "boolean"
+	case "int":
+		schema.Type = "integer"
+	case "float", "number":
+		schema.Type = "number"
+	case "any":
+	default:
+		schema.Type = "string"
+	}
+
+	if patternNode := getPropValueByName(rule, "pattern"); patternNode != nil {
+		// kwalify patterns are enclosed in slashes
+		schema.Pattern = strings.TrimSuffix(strings.TrimPrefix(patternNode.Value, "/"), "/")
+	}
+	if enumNode := getPropValueByName(rule, "enum"); enumNode != nil {
+		for _, item := range enumNode.Content {
+			schema.Enum = append(schema.Enum, item.Value)
+		}
+	}
+	if constNode := getPropValueByName(rule, "const"); constNode != nil {
+		if err := constNode.Decode(&schema.Const); err != nil {
+			return nil, err
+		}
+	}
+	if defaultNode := getPropValueByName(rule, "default"); defaultNode != nil {
+		if err := defaultNode.Decode(&schema.Default); err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+// buildJSONSchemaMapping converts the rules of the mapping keys to the properties of the object
+func buildJSONSchemaMapping(schema *jsonSchema, mappingNode *yaml.Node, definitions *jsonSchemaMap) error {
+	if mappingNode == nil {
+		return nil
+	}
+	if mappingNode.Kind != yaml.MappingNode {
+		return errors.Errorf(jsonSchemaRuleMsg, mappingNode.Line)
+	}
+	for i := 0; i+1 < len(mappingNode.Content); i += 2 {
+		keyNode, ruleNode := mappingNode.Content[i], mappingNode.Content[i+1]
+		property, err := buildJSONSchemaRule(ruleNode, definitions)
+		if err != nil {
+			return err
+		}
+		if keyNode.Value == anyKeyRule {
+			// A rule which allows any value does not restrict the object
+			if !reflect.DeepEqual(property, &jsonSchema{}) {
+				schema.AdditionalProperties = property
+			}
+			continue
+		}
+
+		if len(property.Description) == 0 {
+			property.Description = getCommentText(keyNode.HeadComment)
+		}
+		if requiredNode := getPropValueByName(ruleNode, "required"); requiredNode != nil && requiredNode.Value == "true" {
+			schema.Required = append(schema.Required, keyNode.Value)
+		}
+		if schema.Properties == nil {
+			schema.Properties = newJSONSchemaMap()
+		}
+		schema.Properties.set(keyNode.Value, property)
+	}
+	return nil
+}
+
+// getCommentText returns the text of a YAML comment, with its lines joined
+func getCommentText(comment string) string {
+	var lines []string
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, " ")
+}
@@ -0,0 +1,194 @@
+package validate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSONSchema", func() {
+	It("the LSP schema is generated from the MTA schema", func() {
+		expected, err := ioutil.ReadFile(filepath.Join("..", "lsp", "schema.json"))
+		Ω(err).Should(Succeed())
+		actual, err := BuildJSONSchema(schemaV33Def, "schema_v3.3.yaml")
+		Ω(err).Should(Succeed())
+		Ω(string(actual)).Should(Equal(string(expected)),
+			`the lsp/schema.json file does not match the MTA schema; run "go generate" to regenerate it`)
+	})
+
+	It("keeps the rules of the hooks in the LSP schema", func() {
+		content, err := ioutil.ReadFile(filepath.Join("..", "lsp", "schema.json"))
+		Ω(err).Should(Succeed())
+		var schema struct {
+			ID         string `json:"$id"`
+			Properties struct {
+				Modules struct {
+					Items struct {
+						Properties struct {
+							Hooks struct {
+								Items struct {
+									Properties struct {
+										Type struct {
+											Enum []string `json:"enum"`
+										} `json:"type"`
+										Phases struct {
+											Items struct {
+												Enum []string `json:"enum"`
+											} `json:"items"`
+										} `json:"phases"`
+										Parameters struct {
+											Required   []string `json:"required"`
+											Properties map[string]struct {
+												Ref string `json:"$ref"`
+											} `json:"properties"`
+										} `json:"parameters"`
+									} `json:"properties"`
+								} `json:"items"`
+							} `json:"hooks"`
+						} `json:"properties"`
+					} `json:"items"`
+				} `json:"modules"`
+			} `json:"properties"`
+			Definitions map[string]struct {
+				Pattern string `json:"pattern"`
+			} `json:"definitions"`
+		}
+		Ω(json.Unmarshal(content, &schema)).Should(Succeed())
+		Ω(schema.ID).ShouldNot(BeEmpty())
+
+		hook := schema.Properties.Modules.Items.Properties.Hooks.Items.Properties
+		Ω(hook.Type.Enum).Should(Equal([]string{taskHookType}))
+		var phases []string
+		for phase := range hookPhases {
+			phases = append(phases, phase)
+		}
+		sort.Strings(phases)
+		sort.Strings(hook.Phases.Items.Enum)
+		Ω(hook.Phases.Items.Enum).Should(Equal(phases))
+		Ω(hook.Parameters.Required).Should(Equal([]string{commandParameter}))
+		Ω(hook.Parameters.Properties["memory"].Ref).Should(Equal("#/definitions/generic-memory"))
+		Ω(hook.Parameters.Properties["disk-quota"].Ref).Should(Equal("#/definitions/generic-memory"))
+		Ω(schema.Definitions["generic-memory"].Pattern).ShouldNot(BeEmpty())
+	})
+
+	It("converts the rules, the comments and the anchors", func() {
+		actual, err := BuildJSONSchema([]byte(`
+desc: TEST SCHEMA
+name: test-schema
+type: map
+mapping:
+  # The ID
+  # of the MTA
+  ID: {required: true, pattern: '/^[a-z]+$/'}
+  count: {type: int, default: 1}
+  ratio: {type: float}
+  kind: {enum: [a, b], desc: The kind <a|b>}
+  props: &props
+    type: map
+    mapping: { =: {type: any} }
+  list:
+    type: seq
+    sequence:
+    - type: map
+      mapping:
+        # The properties
+        props: *props
+        flags:
+          type: map
+          mapping: { =: {type: bool} }
+`), "test.yaml")
+		Ω(err).Should(Succeed())
+		Ω(string(actual)).Should(MatchJSON(`{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "test-schema",
+  "$comment": "Generated from the test.yaml MTA schema; do not edit",
+  "description": "TEST SCHEMA",
+  "type": "object",
+  "required": ["ID"],
+  "properties": {
+    "ID": {"description": "The ID of the MTA", "type": "string", "pattern": "^[a-z]+$"},
+    "count": {"type": "integer", "default": 1},
+    "ratio": {"type": "number"},
+    "kind": {"description": "The kind <a|b>", "type": "string", "enum": ["a", "b"]},
+    "props": {"$ref": "#/definitions/props"},
+    "list": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "props": {"description": "The properties", "$ref": "#/definitions/props"},
+          "flags": {"type": "object", "additionalProperties": {"type": "boolean"}}
+        }
+      }
+    }
+  },
+  "definitions": {
+    "props": {"type": "object"}
+  }
+}`))
+		Ω(string(actual)).Should(ContainSubstring("<a|b>"))
+	})
+
+	It("converts the combinators, the array keywords and the definitions", func() {
+		actual, err := BuildJSONSchema([]byte(`
+definitions:
+  name: {pattern: '/^[a-z]+$/'}
+type: map
+additionalProperties: false
+mapping:
+  name: {$ref: "#/definitions/name", required: true}
+  kind: {const: duck}
+  names:
+    type: seq
+    minItems: 1
+    uniqueItems: true
+    sequence:
+    - {$ref: name}
+  value:
+    oneOf:
+    - type: bool
+    - type: int
+  other:
+    anyOf:
+    - type: seq
+      sequence:
+      - type: str
+    - type: str
+`), "test.yaml")
+		Ω(err).Should(Succeed())
+		Ω(string(actual)).Should(MatchJSON(`{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$comment": "Generated from the test.yaml MTA schema; do not edit",
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "name": {"$ref": "#/definitions/name"},
+    "kind": {"type": "string", "const": "duck"},
+    "names": {"type": "array", "minItems": 1, "uniqueItems": true, "items": {"$ref": "#/definitions/name"}},
+    "value": {"oneOf": [{"type": "boolean"}, {"type": "integer"}]},
+    "other": {"anyOf": [{"type": "array", "items": {"type": "string"}}, {"type": "string"}]}
+  },
+  "additionalProperties": false,
+  "definitions": {
+    "name": {"type": "string", "pattern": "^[a-z]+$"}
+  }
+}`))
+	})
+
+	It("fails on an invalid schema", func() {
+		_, err := BuildJSONSchema([]byte("a: [b"), "test.yaml")
+		Ω(err).Should(HaveOccurred())
+		_, err = BuildJSONSchema([]byte("type: map\nmapping:\n  a: [b]\n"), "test.yaml")
+		Ω(err).Should(MatchError(`invalid .yaml file schema: the rule in line 3 must be a map`))
+		_, err = BuildJSONSchema([]byte("type: seq\nsequence: []\n"), "test.yaml")
+		Ω(err).Should(MatchError(`invalid .yaml file schema: the sequence node in line 1 must have exactly one item`))
+		_, err = BuildJSONSchema([]byte("type: map\nmapping: [a]\n"), "test.yaml")
+		Ω(err).Should(HaveOccurred())
+		_, err = BuildJSONSchema([]byte(""), "test.yaml")
+		Ω(err).Should(HaveOccurred())
+	})
+})
@@ -94,13 +94,13 @@ resources:
 
 		datatypeNotAllowedForParametersMetadata := fmt.Sprintf(propertyExistsErrorMsg, datatypeYamlField, parametersMetadataField)
 		Ω(issues).Should(ConsistOf(
-			YamlValidationIssue{datatypeNotAllowedForParametersMetadata, 10},
-			YamlValidationIssue{datatypeNotAllowedForParametersMetadata, 22},
-			YamlValidationIssue{datatypeNotAllowedForParametersMetadata, 33},
-			YamlValidationIssue{datatypeNotAllowedForParametersMetadata, 40},
-			YamlValidationIssue{datatypeNotAllowedForParametersMetadata, 54},
-			YamlValidationIssue{datatypeNotAllowedForParametersMetadata, 63},
-			YamlValidationIssue{datatypeNotAllowedForParametersMetadata, 75},
+			YamlValidationIssue{Msg: datatypeNotAllowedForParametersMetadata, Line: 10},
+			YamlValidationIssue{Msg: datatypeNotAllowedForParametersMetadata, Line: 22},
+			YamlValidationIssue{Msg: datatypeNotAllowedForParametersMetadata, Line: 33},
+			YamlValidationIssue{Msg: datatypeNotAllowedForParametersMetadata, Line: 40},
+			YamlValidationIssue{Msg: datatypeNotAllowedForParametersMetadata, Line: 54},
+			YamlValidationIssue{Msg: datatypeNotAllowedForParametersMetadata, Line: 63},
+			YamlValidationIssue{Msg: datatypeNotAllowedForParametersMetadata, Line: 75},
 		))
 	})
 })
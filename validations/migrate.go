@@ -0,0 +1,269 @@
+package validate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	migrateReadFailedMsg    = `could not read the "%s" file; the migration failed`
+	migrateWriteFailedMsg   = `could not write the "%s" file; the migration failed`
+	migrateParseFailedMsg   = `could not parse the MTA; the migration failed`
+	migrateTargetMsg        = `could not migrate to the "%s" schema version; use one of the following versions: %s`
+	migrateOlderTargetMsg   = `could not migrate from the "%s" schema version to the older "%s" schema version`
+	migrateSchemaVersionMsg = `line %d: updated the "_schema-version" from "%s" to "%s"`
+	migrateAddSchemaMsg     = `line %d: added the "_schema-version" with the "%s" value`
+	migrateOptMsg           = `line %d: replaced the "%s" build parameter of the "%s" module with the "custom" builder and the %s commands`
+	migrateOptManuallyMsg   = `line %d: the "%s" build parameter of the "%s" module could not be migrated: %s; migrate it manually to the "custom" builder; see %q`
+	migrateOptBuilderMsg    = `the "%s" builder does not support it`
+	migrateOptCommandsMsg   = `the module already has build commands`
+	migrateOptValueMsg      = `the "%s" option has a nested value`
+	migrateOptValueKindMsg  = `its value is not a string, a list or a map`
+	migrateOptEditMsg       = `its value cannot be replaced without changing the formatting of the file`
+	migrateEditFailedMsg    = `could not edit the MTA without changing its formatting; the migration failed`
+	migrateInvalidMsg       = `the "%s" file migrated to the "%s" schema version is not valid; the file was not changed: ` + "\n%s"
+)
+
+// optBuilder describes how a deprecated build option is converted to the commands of the "custom" builder
+type optBuilder struct {
+	// the builders which support the option
+	builders []string
+	// the commands the builders run
+	commands []string
+	// the index of the command which gets the options as arguments
+	optsCommand int
+}
+
+var optBuilders = map[string]optBuilder{
+	npmOptsYamlField:   {[]string{"npm"}, []string{"npm install --production"}, 0},
+	gruntOptsYamlField: {[]string{"grunt"}, []string{"npm install", "grunt", "npm prune --production"}, 1},
+	mavenOptsYamlField: {[]string{"maven", "mvn"}, []string{"mvn -B package"}, 0},
+}
+
+// Migrate updates the MTA file to the target schema version and converts the deprecated constructs.
+// Only the migrated parts of the file are changed, so its comments and formatting are preserved.
+// The migrated MTA is validated against the schema of the target version, and the file is not written
+// if it is not valid. It returns the report of the transformations applied.
+func Migrate(path string, to string) ([]string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, migrateReadFailedMsg, path)
+	}
+	content, report, err := migrate(content, to)
+	if err != nil {
+		return nil, err
+	}
+	yamlContent := []byte(strings.Replace(string(content), "\r\n", "\r", -1))
+	errIssues, _ := validateWithSchemaVersion(yamlContent, filepath.Dir(path), filepath.Base(path), to, true, false, true, "")
+	if len(errIssues) > 0 {
+		errIssues.Sort()
+		return nil, errors.Errorf(migrateInvalidMsg, path, to, errIssues.String())
+	}
+	err = ioutil.WriteFile(path, content, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, migrateWriteFailedMsg, path)
+	}
+	return report, nil
+}
+
+// migrate returns the MTA content migrated to the target schema version, with the report of the transformations applied
+func migrate(content []byte, to string) ([]byte, []string, error) {
+	target, ok := getSchemaVersion(to)
+	if !ok {
+		return nil, nil, errors.Errorf(migrateTargetMsg, to, getSupportedSchemaVersions())
+	}
+
+	var document yaml.Node
+	err := yaml.Unmarshal(content, &document)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, migrateParseFailedMsg)
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode || len(document.Content[0].Content) == 0 {
+		return nil, nil, errors.New(migrateParseFailedMsg)
+	}
+	mtaNode := document.Content[0]
+
+	// The transformations are applied as text edits, like the quick fixes
+	var issues []YamlValidationIssue
+	report, fix, err := migrateSchemaVersion(mtaNode, target)
+	if err != nil {
+		return nil, nil, err
+	}
+	if fix != nil {
+		issues = append(issues, YamlValidationIssue{Fix: fix})
+	}
+	for _, moduleNode := range getPropContent(mtaNode, modulesYamlField) {
+		moduleReport, moduleFixes := migrateDeprecatedOpts(moduleNode)
+		report = append(report, moduleReport...)
+		for _, fix := range moduleFixes {
+			issues = append(issues, YamlValidationIssue{Fix: fix})
+		}
+	}
+
+	migrated, fixed := applyFixes(content, issues)
+	if len(fixed) != len(issues) {
+		return nil, nil, errors.New(migrateEditFailedMsg)
+	}
+	return migrated, report, nil
+}
+
+// migrateSchemaVersion returns the fix which sets the "_schema-version" of the MTA to the target schema version.
+// Migrating to an older schema version is not supported.
+func migrateSchemaVersion(mtaNode *yaml.Node, target schemaVersion) ([]string, *YamlFix, error) {
+	versionNode := getPropValueByName(mtaNode, schemaVersionYamlField)
+	if versionNode == nil {
+		if mtaNode.Style&yaml.FlowStyle != 0 {
+			return nil, nil, errors.New(migrateEditFailedMsg)
+		}
+		fix := newFix(schemaVersionYamlField, getInsertBeforeKeyEdit(mtaNode.Content[0],
+			schemaVersionYamlField+": "+getVersionText(target.String(), yaml.SingleQuotedStyle)))
+		return []string{fmt.Sprintf(migrateAddSchemaMsg, mtaNode.Line, target)}, fix, nil
+	}
+
+	current, ok := getSchemaVersion(versionNode.Value)
+	if ok && target.isBefore(current.major, current.minor) {
+		return nil, nil, errors.Errorf(migrateOlderTargetMsg, versionNode.Value, target)
+	}
+	if ok && current == target && versionNode.Value == target.String() {
+		return nil, nil, nil
+	}
+	edit, ok := getReplaceEdit(versionNode, versionNode, getNextNode(mtaNode, versionNode), getVersionText(target.String(), versionNode.Style))
+	if !ok {
+		return nil, nil, errors.New(migrateEditFailedMsg)
+	}
+	report := []string{fmt.Sprintf(migrateSchemaVersionMsg, versionNode.Line, versionNode.Value, target)}
+	return report, newFix(schemaVersionYamlField, edit), nil
+}
+
+// getVersionText returns the YAML text of the version, which keeps the quotes of the current value; a version
+// without quotes is quoted, so it is not parsed as a number
+func getVersionText(version string, style yaml.Style) string {
+	if style&yaml.DoubleQuotedStyle != 0 {
+		return `"` + version + `"`
+	}
+	return "'" + version + "'"
+}
+
+// migrateDeprecatedOpts returns the fixes which replace the deprecated build options of the module with
+// the commands of the "custom" builder
+func migrateDeprecatedOpts(moduleNode *yaml.Node) ([]string, []*YamlFix) {
+	var report []string
+	var fixes []*YamlFix
+	moduleName := ""
+	if nameNode := getPropValueByName(moduleNode, nameYamlField); nameNode != nil {
+		moduleName = nameNode.Value
+	}
+	buildParamsNode := getPropValueByName(moduleNode, buildParametersYamlField)
+	if buildParamsNode != nil && buildParamsNode.Kind == yaml.AliasNode {
+		buildParamsNode = buildParamsNode.Alias
+	}
+	if buildParamsNode == nil || buildParamsNode.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	converted := false
+	for _, opt := range []string{npmOptsYamlField, gruntOptsYamlField, mavenOptsYamlField} {
+		optKeyNode := getPropByName(buildParamsNode, opt)
+		if optKeyNode == nil {
+			continue
+		}
+		commands, reason := getOptCommands(buildParamsNode, opt)
+		var fix *YamlFix
+		switch {
+		// The builder was already replaced by the "custom" builder for another option
+		case converted:
+			reason = fmt.Sprintf(migrateOptBuilderMsg, customBuilder)
+		case len(reason) == 0:
+			if fix = getDeprecatedOptFix(buildParamsNode, opt); fix == nil {
+				reason = migrateOptEditMsg
+			}
+		}
+		if len(reason) > 0 {
+			report = append(report, fmt.Sprintf(migrateOptManuallyMsg, optKeyNode.Line, opt, moduleName, reason, customBuilderDocLink))
+			continue
+		}
+
+		converted = true
+		fixes = append(fixes, fix)
+		quoted := make([]string, len(commands))
+		for i, command := range commands {
+			quoted[i] = fmt.Sprintf("%q", command)
+		}
+		report = append(report, fmt.Sprintf(migrateOptMsg, optKeyNode.Line, opt, moduleName, strings.Join(quoted, ", ")))
+	}
+	return report, fixes
+}
+
+// getOptCommands returns the commands of the "custom" builder which replace the deprecated build option,
+// or the reason it cannot be converted
+func getOptCommands(buildParamsNode *yaml.Node, opt string) ([]string, string) {
+	builder := optBuilders[opt]
+	builderNode := getPropValueByName(buildParamsNode, builderYamlField)
+	if builderNode == nil || !containsString(builder.builders, builderNode.Value) {
+		builderName := ""
+		if builderNode != nil {
+			builderName = builderNode.Value
+		}
+		return nil, fmt.Sprintf(migrateOptBuilderMsg, builderName)
+	}
+	if getPropByName(buildParamsNode, commandsYamlField) != nil {
+		return nil, migrateOptCommandsMsg
+	}
+
+	args, reason := getOptArgs(getPropValueByName(buildParamsNode, opt))
+	if len(reason) > 0 {
+		return nil, reason
+	}
+	commands := append([]string{}, builder.commands...)
+	if len(args) > 0 {
+		commands[builder.optsCommand] += " " + strings.Join(args, " ")
+	}
+	return commands, ""
+}
+
+// getOptArgs converts the value of a deprecated build option to command line arguments:
+// a string is used as is, the items of a list are arguments,
+// and the keys of a map are flags, with their values if they are not booleans
+func getOptArgs(node *yaml.Node) ([]string, string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.ShortTag() == "!!null" || len(node.Value) == 0 {
+			return nil, ""
+		}
+		return []string{node.Value}, ""
+	case yaml.SequenceNode:
+		var args []string
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return nil, migrateOptValueKindMsg
+			}
+			args = append(args, item.Value)
+		}
+		return args, ""
+	case yaml.MappingNode:
+		var args []string
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if value.Kind != yaml.ScalarNode {
+				return nil, fmt.Sprintf(migrateOptValueMsg, key)
+			}
+			switch {
+			case value.ShortTag() == "!!bool" && value.Value == "true":
+				args = append(args, "--"+key)
+			case value.ShortTag() == "!!bool" || value.ShortTag() == "!!null":
+			default:
+				args = append(args, fmt.Sprintf("--%s=%s", key, value.Value))
+			}
+		}
+		return args, ""
+	}
+	return nil, migrateOptValueKindMsg
+}
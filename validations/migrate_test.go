@@ -0,0 +1,236 @@
+package validate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Migrate", func() {
+	It("migrates the schema version and the deprecated options, preserving the comments", func() {
+		content := []byte(`# The MTA of the application
+ID: mtahtml5
+_schema-version: '2.1'
+version: 0.0.1
+
+modules:
+  # The UI module
+  - name: ui5app1
+    type: html5
+    build-parameters:
+      builder: npm
+      # install without the optional packages
+      npm-opts:
+        no-optional: true
+        registry: http://registry
+        global: false
+  - name: ui5app2
+    type: html5
+    build-parameters:
+      builder: grunt
+      grunt-opts:
+        - --force
+  - name: java
+    type: java
+    build-parameters:
+      builder: maven
+      maven-opts: -DskipTests
+`)
+		result, report, err := migrate(content, "3.3")
+		Ω(err).Should(Succeed())
+		Ω(string(result)).Should(Equal(`# The MTA of the application
+ID: mtahtml5
+_schema-version: '3.3'
+version: 0.0.1
+
+modules:
+  # The UI module
+  - name: ui5app1
+    type: html5
+    build-parameters:
+      builder: custom
+      # install without the optional packages
+      commands:
+        - npm install --production --no-optional --registry=http://registry
+  - name: ui5app2
+    type: html5
+    build-parameters:
+      builder: custom
+      commands:
+        - npm install
+        - grunt --force
+        - npm prune --production
+  - name: java
+    type: java
+    build-parameters:
+      builder: custom
+      commands:
+        - mvn -B package -DskipTests
+`))
+		Ω(report).Should(Equal([]string{
+			`line 3: updated the "_schema-version" from "2.1" to "3.3"`,
+			`line 13: replaced the "npm-opts" build parameter of the "ui5app1" module with the "custom" builder and the "npm install --production --no-optional --registry=http://registry" commands`,
+			`line 21: replaced the "grunt-opts" build parameter of the "ui5app2" module with the "custom" builder and the "npm install", "grunt --force", "npm prune --production" commands`,
+			`line 27: replaced the "maven-opts" build parameter of the "java" module with the "custom" builder and the "mvn -B package -DskipTests" commands`,
+		}))
+	})
+
+	It("reports the deprecated options which cannot be migrated", func() {
+		content := []byte(`ID: mtahtml5
+_schema-version: '3.1'
+version: 0.0.1
+modules:
+  - name: ui5app1
+    type: html5
+    build-parameters:
+      builder: grunt
+      npm-opts: abc
+  - name: ui5app2
+    type: html5
+    build-parameters:
+      builder: npm
+      commands: [npm ci]
+      npm-opts: abc
+  - name: ui5app3
+    type: html5
+    build-parameters:
+      builder: npm
+      npm-opts:
+        a:
+          b: c
+`)
+		result, report, err := migrate(content, "3")
+		Ω(err).Should(Succeed())
+		Ω(string(result)).Should(ContainSubstring(`_schema-version: '3.3'`))
+		Ω(string(result)).Should(ContainSubstring(`npm-opts: abc`))
+		Ω(report).Should(Equal([]string{
+			`line 2: updated the "_schema-version" from "3.1" to "3.3"`,
+			fmt.Sprintf(migrateOptManuallyMsg, 9, npmOptsYamlField, "ui5app1", `the "grunt" builder does not support it`, customBuilderDocLink),
+			fmt.Sprintf(migrateOptManuallyMsg, 15, npmOptsYamlField, "ui5app2", migrateOptCommandsMsg, customBuilderDocLink),
+			fmt.Sprintf(migrateOptManuallyMsg, 20, npmOptsYamlField, "ui5app3", `the "a" option has a nested value`, customBuilderDocLink),
+		}))
+	})
+
+	It("reports the deprecated options with values on several lines", func() {
+		content := []byte(`ID: mtahtml5
+_schema-version: '3.1'
+version: 0.0.1
+modules:
+  - name: ui5app1
+    type: html5
+    build-parameters:
+      builder: npm
+      npm-opts:
+        registry: http://registry
+          /npm
+  - name: java
+    type: java
+    build-parameters:
+      builder: maven
+      maven-opts: -DskipTests
+        -Pprod
+      timeout: 10m
+`)
+		result, report, err := migrate(content, "3.3")
+		Ω(err).Should(Succeed())
+		Ω(string(result)).Should(Equal(strings.Replace(string(content), "'3.1'", "'3.3'", 1)))
+		Ω(report).Should(Equal([]string{
+			`line 2: updated the "_schema-version" from "3.1" to "3.3"`,
+			fmt.Sprintf(migrateOptManuallyMsg, 9, npmOptsYamlField, "ui5app1", migrateOptEditMsg, customBuilderDocLink),
+			fmt.Sprintf(migrateOptManuallyMsg, 16, mavenOptsYamlField, "java", migrateOptEditMsg, customBuilderDocLink),
+		}))
+	})
+
+	It("adds a missing schema version", func() {
+		result, report, err := migrate([]byte("ID: mtahtml5\nversion: 0.0.1\n"), "3.2")
+		Ω(err).Should(Succeed())
+		Ω(string(result)).Should(Equal("_schema-version: '3.2'\nID: mtahtml5\nversion: 0.0.1\n"))
+		Ω(report).Should(Equal([]string{`line 1: added the "_schema-version" with the "3.2" value`}))
+	})
+
+	It("does not change an MTA with the target schema version", func() {
+		_, report, err := migrate([]byte("ID: mtahtml5\n_schema-version: '3.2'\nversion: 0.0.1\n"), "3.2")
+		Ω(err).Should(Succeed())
+		Ω(report).Should(BeEmpty())
+	})
+
+	It("fails on an older target schema version", func() {
+		_, _, err := migrate([]byte("ID: mtahtml5\n_schema-version: '3.2'\nversion: 0.0.1\n"), "2.1")
+		Ω(err).Should(MatchError(`could not migrate from the "3.2" schema version to the older "2.1" schema version`))
+	})
+
+	It("fails on an unsupported target schema version", func() {
+		_, _, err := migrate([]byte("ID: mtahtml5\n"), "4")
+		Ω(err).Should(MatchError(fmt.Sprintf(migrateTargetMsg, "4", getSupportedSchemaVersions())))
+	})
+
+	It("fails on invalid content", func() {
+		_, _, err := migrate([]byte("- a\n- b\n"), "3.3")
+		Ω(err).Should(MatchError(migrateParseFailedMsg))
+	})
+
+	It("rewrites the MTA file", func() {
+		dir, err := ioutil.TempDir("", "migrate")
+		Ω(err).Should(Succeed())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "mta.yaml")
+		Ω(ioutil.WriteFile(path, []byte("ID: mtahtml5\n_schema-version: '2.1'\nversion: 0.0.1\n"), 0644)).Should(Succeed())
+		report, err := Migrate(path, "3.3")
+		Ω(err).Should(Succeed())
+		Ω(report).Should(HaveLen(1))
+		content, err := ioutil.ReadFile(path)
+		Ω(err).Should(Succeed())
+		Ω(string(content)).Should(Equal("ID: mtahtml5\n_schema-version: '3.3'\nversion: 0.0.1\n"))
+	})
+
+	It("keeps the indentation and the quotes of the MTA", func() {
+		content := []byte(`ID: "mtahtml5"
+_schema-version: "3.1"
+version: 0.0.1
+modules:
+    -   name: "java"
+        type: java
+        build-parameters:
+            builder: maven
+            maven-opts: -DskipTests
+`)
+		result, _, err := migrate(content, "3.3")
+		Ω(err).Should(Succeed())
+		Ω(string(result)).Should(Equal(`ID: "mtahtml5"
+_schema-version: "3.3"
+version: 0.0.1
+modules:
+    -   name: "java"
+        type: java
+        build-parameters:
+            builder: custom
+            commands:
+              - mvn -B package -DskipTests
+`))
+	})
+
+	It("does not rewrite the MTA file when the migrated MTA is not valid", func() {
+		dir, err := ioutil.TempDir("", "migrate")
+		Ω(err).Should(Succeed())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "mta.yaml")
+		content := []byte("ID: mta id\n_schema-version: '2.1'\nversion: 0.0.1\n")
+		Ω(ioutil.WriteFile(path, content, 0644)).Should(Succeed())
+		_, err = Migrate(path, "3.3")
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(HavePrefix(fmt.Sprintf(migrateInvalidMsg, path, "3.3", "line 1: ")))
+		result, err := ioutil.ReadFile(path)
+		Ω(err).Should(Succeed())
+		Ω(result).Should(Equal(content))
+	})
+
+	It("fails on a missing MTA file", func() {
+		_, err := Migrate(filepath.Join("testdata", "missing", "mta.yaml"), "3.3")
+		Ω(err).Should(HaveOccurred())
+	})
+})
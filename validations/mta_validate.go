@@ -30,7 +30,15 @@ func GetValidationMode(validationFlag string) (bool, bool, error) {
 }
 
 // MtaYaml validates an MTA.yaml file.
+// The schema is selected by the "_schema-version" of the MTA.
 func MtaYaml(projectPath, mtaFilename string,
+	validateSchema, validateSemantic, strict bool, exclude string) (warning string, err error) {
+	return MtaYamlWithSchemaVersion(projectPath, mtaFilename, "", validateSchema, validateSemantic, strict, exclude)
+}
+
+// MtaYamlWithSchemaVersion validates an MTA.yaml file with the schema of the schema version,
+// instead of the schema selected by the "_schema-version" of the MTA, if the schema version is not empty.
+func MtaYamlWithSchemaVersion(projectPath, mtaFilename, schemaVersion string,
 	validateSchema, validateSemantic, strict bool, exclude string) (warning string, err error) {
 	if validateSemantic || validateSchema {
 
@@ -45,7 +53,7 @@ func MtaYaml(projectPath, mtaFilename string,
 		s = strings.Replace(s, "\r\n", "\r", -1)
 		yamlContent = []byte(s)
 		// Validates MTA content.
-		errIssues, warnIssues := validate(yamlContent, projectPath,
+		errIssues, warnIssues := validateWithSchemaVersion(yamlContent, projectPath, mtaFilename, schemaVersion,
 			validateSchema, validateSemantic, strict, exclude)
 		errIssues.Sort()
 		warnIssues.Sort()
@@ -70,9 +78,16 @@ func readFile(file string) ([]byte, error) {
 	return content, nil
 }
 
-// validate - validates the MTA descriptor
+// validate - validates the MTA descriptor with the schema selected by its "_schema-version"
 func validate(yamlContent []byte, projectPath string,
 	validateSchema, validateSemantic, strict bool, exclude string) (errIssues YamlValidationIssues, warnIssues YamlValidationIssues) {
+	return validateWithSchemaVersion(yamlContent, projectPath, "", "", validateSchema, validateSemantic, strict, exclude)
+}
+
+// validateWithSchemaVersion - validates the MTA descriptor with the schema of the schema version, if it is not empty.
+// The file name of the descriptor selects the custom rules which apply to it.
+func validateWithSchemaVersion(yamlContent []byte, projectPath string, mtaFilename string, schemaVersion string,
+	validateSchema, validateSemantic, strict bool, exclude string) (errIssues YamlValidationIssues, warnIssues YamlValidationIssues) {
 
 	mtaStr, err := mta.Unmarshal(yamlContent)
 
@@ -87,37 +102,60 @@ func validate(yamlContent []byte, projectPath string,
 		errIssues = convertError(err)
 	}
 
-	if validateSchema {
-		validations, schemaValidationLog := buildValidationsFromSchemaText(schemaDef)
+	config, configIssues := loadRuleConfig(projectPath)
+	errIssues = append(errIssues, configIssues...)
+	rules := newRuleContext(config, mtaNode, mtaFilename, strict)
+
+	if validateSchema && rules.isEnabled(schemaValidation) {
+		version, versionIssues := selectSchemaVersion(mtaNode, schemaVersion)
+		errIssues = append(errIssues, versionIssues...)
+		validations, schemaValidationLog := buildValidationsFromSchemaText(*version.def)
 		if len(schemaValidationLog) > 0 {
 			errIssues = append(errIssues, schemaValidationLog...)
 			return errIssues, warnIssues
 		}
-		errIssues = append(errIssues, runSchemaValidations(mtaNode, validations...)...)
-
-		issues := checkBuilderSchema(mtaStr, mtaNode, "")
-		if strict {
-			errIssues = append(errIssues, issues...)
-		} else {
-			warnIssues = append(warnIssues, issues...)
-		}
-
-		issues = checkMetadataSchema(mtaStr, mtaNode, "")
-		if strict {
-			errIssues = append(errIssues, issues...)
-		} else {
-			warnIssues = append(warnIssues, issues...)
-		}
+		errs, warns := validateMtaSchema(mtaStr, mtaNode, version, validations, rules.isStrict(schemaValidation))
+		errs, warns = rules.apply(schemaValidation, errs, warns)
+		errIssues = append(errIssues, errs...)
+		warnIssues = append(warnIssues, warns...)
 	}
 
 	if validateSemantic {
-		errs, warns := runSemanticValidations(mtaStr, mtaNode, projectPath, exclude, strict)
+		errs, warns := runRuleSemanticValidations(mtaStr, mtaNode, projectPath, exclude, rules)
 		errIssues = append(errIssues, errs...)
 		warnIssues = append(warnIssues, warns...)
 	}
 	return errIssues, warnIssues
 }
 
+// validateMtaSchema - validates the MTA descriptor with the validations of its schema version
+func validateMtaSchema(mtaStr *mta.MTA, mtaNode *yaml.Node, version schemaVersion, validations []YamlCheck,
+	strict bool) (errIssues YamlValidationIssues, warnIssues YamlValidationIssues) {
+	errIssues = append(errIssues, runSchemaValidations(mtaNode, validations...)...)
+
+	issues := checkBuilderSchema(mtaStr, mtaNode, "")
+	if strict {
+		errIssues = append(errIssues, issues...)
+	} else {
+		warnIssues = append(warnIssues, issues...)
+	}
+
+	issues = checkMetadataSchema(mtaStr, mtaNode, "")
+	if strict {
+		errIssues = append(errIssues, issues...)
+	} else {
+		warnIssues = append(warnIssues, issues...)
+	}
+
+	issues = checkSchemaVersionFeatures(mtaNode, version)
+	if strict {
+		errIssues = append(errIssues, issues...)
+	} else {
+		warnIssues = append(warnIssues, issues...)
+	}
+	return errIssues, warnIssues
+}
+
 // convertError - converts unmarshalling errors to the YamlValidationIssue format
 // extracting line number to issue Line property
 func convertError(err error) []YamlValidationIssue {
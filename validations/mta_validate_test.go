@@ -108,7 +108,9 @@ var _ = Describe("MTA tests", func() {
 			It("strict", func() {
 				warn, err := MtaYaml(getTestPath("mtahtml5"), "mtaNotStrict.yaml",
 					true, true, true, "")
-				Ω(warn).Should(Equal(""))
+				Ω(warn).Should(ContainSubstring(`line 17: the "srv_api" property set provided by the "srv" module is not required by any module, hook or resource and is not public`))
+				Ω(warn).Should(ContainSubstring(`line 46: the "hdi_db" resource is not required by any module, hook or resource`))
+				Ω(warn).ShouldNot(ContainSubstring("line 8: field abc not found in type mta.Module"))
 				fmt.Println(err.Error())
 				Ω(err.Error()).Should(ContainSubstring("line 8: field abc not found in type mta.Module"))
 				Ω(err.Error()).Should(ContainSubstring(`line 20: mapping key "url" already defined at line 19`))
@@ -147,8 +149,9 @@ var _ = Describe("MTA tests", func() {
 			})
 
 			It("invalid schema", func() {
-				originalSchema := schemaDef
-				schemaDef = []byte(`
+				// the MTA in the test project uses the 2.1 schema version
+				originalSchema := schemaV21Def
+				schemaV21Def = []byte(`
 desc: MTA DESCRIPTOR SCHEMA
 # schema version must be extracted from here as there is no "version" element available to version schemas
   name: com.sap.mta.mta-schema_3.2.0 abc
@@ -156,7 +159,7 @@ desc: MTA DESCRIPTOR SCHEMA
 				_, err := MtaYaml(getTestPath("testproject"), "mta.yaml",
 					true, false, true, "")
 				Ω(err).Should(HaveOccurred())
-				schemaDef = originalSchema
+				schemaV21Def = originalSchema
 			})
 		})
 
@@ -194,12 +197,12 @@ modules:
 					true, false, true, "")
 				Ω(warn).Should(BeNil())
 				Ω(err).Should(ConsistOf(
-					YamlValidationIssue{"cannot unmarshal !!str `abc` into bool", 10},
-					YamlValidationIssue{`the "parameters-metadata.param1.overwritable" property must be a boolean`, 10},
-					YamlValidationIssue{"cannot unmarshal !!int `12` into bool", 19},
-					YamlValidationIssue{`the "modules[0].parameters-metadata.memory.optional" property must be a boolean`, 19},
-					YamlValidationIssue{"cannot unmarshal !!str `is it?` into bool", 25},
-					YamlValidationIssue{`the "some_type" value of the "modules[0].properties-metadata.a.datatype" enum property is invalid; expected one of the following: str,int,float,bool`, 26},
+					YamlValidationIssue{Msg: "cannot unmarshal !!str `abc` into bool", Line: 10},
+					YamlValidationIssue{Msg: `the "parameters-metadata.param1.overwritable" property must be a boolean`, Line: 10},
+					YamlValidationIssue{Msg: "cannot unmarshal !!int `12` into bool", Line: 19},
+					YamlValidationIssue{Msg: `the "modules[0].parameters-metadata.memory.optional" property must be a boolean`, Line: 19},
+					YamlValidationIssue{Msg: "cannot unmarshal !!str `is it?` into bool", Line: 25},
+					YamlValidationIssue{Msg: `the "some_type" value of the "modules[0].properties-metadata.a.datatype" enum property is invalid; expected one of the following: str,int,float,bool`, Line: 26},
 				))
 			})
 
@@ -236,7 +239,7 @@ modules:
 			It("gives an error for datatype field in parameters-metadata", func() {
 				err, warn := validate([]byte(`
 ID: mtahtml5
-_schema-version: '2.1'
+_schema-version: '3.1'
 version: 0.0.1
 
 parameters:
@@ -273,9 +276,9 @@ resources:
 
 				datatypeNotAllowedForParametersMetadata := fmt.Sprintf(propertyExistsErrorMsg, datatypeYamlField, parametersMetadataField)
 				Ω(err).Should(ConsistOf(
-					YamlValidationIssue{datatypeNotAllowedForParametersMetadata, 10},
-					YamlValidationIssue{datatypeNotAllowedForParametersMetadata, 22},
-					YamlValidationIssue{datatypeNotAllowedForParametersMetadata, 33},
+					YamlValidationIssue{Msg: datatypeNotAllowedForParametersMetadata, Line: 10},
+					YamlValidationIssue{Msg: datatypeNotAllowedForParametersMetadata, Line: 22},
+					YamlValidationIssue{Msg: datatypeNotAllowedForParametersMetadata, Line: 33},
 				))
 			})
 
@@ -0,0 +1,323 @@
+package validate
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ruleConfigParseFailedMsg = `could not parse the "%s" rule configuration file: %s`
+	unknownRuleMsg           = `the "%s" rule in the "%s" rule configuration file is not supported; use one of the following rules: %s`
+	unknownSeverityMsg       = `the "%s" severity of the "%s" rule in the "%s" rule configuration file is not supported; use "off", "warn" or "error"`
+	ruleConfigTypesFailedMsg = `could not load the types of the "%s" rule configuration file: %s`
+
+	// The rule configuration file in the project folder
+	ruleConfigFileName = ".mtavalidate.yaml"
+	// The comment which suppresses the issues of the next line; it is followed by the rules to suppress, or by nothing
+	// to suppress the issues of all the rules
+	disableNextLineDirective = "mta-validate-disable-next-line"
+	// The rule of the schema validations
+	schemaValidation = "schema"
+	// The rule name, the map key or the sequence item which matches all of them
+	anyRule = "*"
+
+	severityOff   = "off"
+	severityWarn  = "warn"
+	severityError = "error"
+)
+
+// ruleConfig - the configuration of the validation rules of a project, read from its ".mtavalidate.yaml" file
+type ruleConfig struct {
+	// Ignore - the paths of the MTA nodes which are not validated
+	Ignore []string `yaml:"ignore"`
+	// Rules - the configuration of the rules by their names
+	Rules map[string]ruleSettings `yaml:"rules"`
+	// CustomRules - the validation rules declared by the teams
+	CustomRules []customRule `yaml:"custom-rules"`
+	// Types - the paths of the types definition files, relative to the project folder; their module types
+	// and resource types are added to the built-in types checked by the "types" rule
+	Types []string `yaml:"types"`
+
+	// the built-in types and the types of the types definition files
+	registry *TypeRegistry
+}
+
+// ruleSettings - the configuration of a validation rule
+type ruleSettings struct {
+	// Severity - "off" disables the rule, "warn" and "error" report all its issues as warnings or as errors
+	Severity string `yaml:"severity"`
+	// Strict - replaces the strict flag of the validation for the rule
+	Strict *bool `yaml:"strict"`
+	// Ignore - the paths of the MTA nodes which are not validated by the rule
+	Ignore []string `yaml:"ignore"`
+}
+
+// getRuleNames returns the names of the rules which can be configured
+func getRuleNames() []string {
+	names := []string{schemaValidation}
+	// only the names of the validations are used, so they do not need the types
+	for _, validation := range getSemanticValidations("", nil) {
+		names = append(names, validation.name)
+	}
+	return names
+}
+
+// loadRuleConfig reads the rule configuration file of the project; a missing file is not an issue
+func loadRuleConfig(projectPath string) (*ruleConfig, []YamlValidationIssue) {
+	configPath := filepath.Join(projectPath, ruleConfigFileName)
+	content, err := ioutil.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, []YamlValidationIssue{{Msg: fmt.Sprintf(ruleConfigParseFailedMsg, configPath, err.Error())}}
+	}
+	config, issues := parseRuleConfig(content, configPath)
+	if config == nil || len(config.Types) == 0 {
+		return config, issues
+	}
+	config.registry = NewTypeRegistry()
+	for _, typesPath := range config.Types {
+		if err = config.registry.LoadFile(filepath.Join(projectPath, typesPath)); err != nil {
+			issues = append(issues, YamlValidationIssue{Msg: fmt.Sprintf(ruleConfigTypesFailedMsg, configPath, err.Error())})
+		}
+	}
+	if len(issues) > 0 {
+		return nil, issues
+	}
+	return config, nil
+}
+
+func parseRuleConfig(content []byte, configPath string) (*ruleConfig, []YamlValidationIssue) {
+	var config ruleConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	// An empty file is a valid configuration
+	if err := decoder.Decode(&config); err != nil && len(bytes.TrimSpace(content)) > 0 {
+		return nil, []YamlValidationIssue{{Msg: fmt.Sprintf(ruleConfigParseFailedMsg, configPath, err.Error())}}
+	}
+
+	issues := validateCustomRules(config.CustomRules, configPath)
+	ruleNames := getRuleNames()
+	for _, rule := range config.CustomRules {
+		ruleNames = append(ruleNames, rule.ID)
+	}
+	var names []string
+	for name := range config.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !containsString(ruleNames, name) {
+			issues = append(issues, YamlValidationIssue{
+				Msg: fmt.Sprintf(unknownRuleMsg, name, configPath, strings.Join(ruleNames, ", "))})
+		}
+		severity := config.Rules[name].Severity
+		if len(severity) > 0 && !containsString([]string{severityOff, severityWarn, severityError}, severity) {
+			issues = append(issues, YamlValidationIssue{Msg: fmt.Sprintf(unknownSeverityMsg, severity, name, configPath)})
+		}
+	}
+	if len(issues) > 0 {
+		return nil, issues
+	}
+	return &config, nil
+}
+
+// lineRange - the lines of a node, including its last line
+type lineRange struct {
+	start int
+	end   int
+}
+
+// ruleContext - applies the rule configuration and the suppression comments of an MTA to the issues of its rules
+type ruleContext struct {
+	config *ruleConfig
+	// the name of the descriptor file
+	file   string
+	strict bool
+	// the module types and resource types checked by the "types" rule
+	types *TypeRegistry
+	// the rules suppressed by the comments, by the lines they apply to
+	suppressions map[int][]string
+	// the lines which are not validated by any rule
+	ignored []lineRange
+	// the lines which are not validated, by rule
+	ruleIgnored map[string][]lineRange
+}
+
+func newRuleContext(config *ruleConfig, root *yaml.Node, file string, strict bool) *ruleContext {
+	c := &ruleContext{config: config, file: file, strict: strict, suppressions: make(map[int][]string), ruleIgnored: make(map[string][]lineRange)}
+	if config != nil && config.registry != nil {
+		c.types = config.registry
+	} else {
+		c.types = NewTypeRegistry()
+	}
+	if root == nil {
+		return c
+	}
+	collectSuppressions(root, c.suppressions)
+	if config == nil {
+		return c
+	}
+	for _, path := range config.Ignore {
+		c.ignored = append(c.ignored, getPathRanges(root, strings.Split(path, "."), root.Line)...)
+	}
+	for name, settings := range config.Rules {
+		for _, path := range settings.Ignore {
+			c.ruleIgnored[name] = append(c.ruleIgnored[name], getPathRanges(root, strings.Split(path, "."), root.Line)...)
+		}
+	}
+	return c
+}
+
+func (c *ruleContext) getSettings(rule string) ruleSettings {
+	if c.config == nil {
+		return ruleSettings{}
+	}
+	return c.config.Rules[rule]
+}
+
+// getCustomRules returns the custom rules which apply to the descriptor file
+func (c *ruleContext) getCustomRules() []customRule {
+	var rules []customRule
+	if c.config == nil {
+		return nil
+	}
+	for _, rule := range c.config.CustomRules {
+		if rule.appliesTo(c.file) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// isEnabled returns false if the severity of the rule is "off"
+func (c *ruleContext) isEnabled(rule string) bool {
+	return c.getSettings(rule).Severity != severityOff
+}
+
+// isStrict returns the strict flag of the rule, or the strict flag of the validation if the rule does not define it
+func (c *ruleContext) isStrict(rule string) bool {
+	if strict := c.getSettings(rule).Strict; strict != nil {
+		return *strict
+	}
+	return c.strict
+}
+
+// apply removes the suppressed and ignored issues of the rule, and applies the severity of the rule to the rest
+func (c *ruleContext) apply(rule string, errors, warnings []YamlValidationIssue) ([]YamlValidationIssue, []YamlValidationIssue) {
+	errors = c.filter(rule, errors)
+	warnings = c.filter(rule, warnings)
+	switch c.getSettings(rule).Severity {
+	case severityWarn:
+		return nil, append(warnings, errors...)
+	case severityError:
+		return append(errors, warnings...), nil
+	}
+	return errors, warnings
+}
+
+func (c *ruleContext) filter(rule string, issues []YamlValidationIssue) []YamlValidationIssue {
+	var result []YamlValidationIssue
+	for _, issue := range issues {
+		if !c.isSuppressed(rule, issue.Line) {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+func (c *ruleContext) isSuppressed(rule string, line int) bool {
+	// The issues which are not related to a line cannot be suppressed
+	if line <= 0 {
+		return false
+	}
+	rules := c.suppressions[line]
+	if containsString(rules, rule) || containsString(rules, anyRule) {
+		return true
+	}
+	return inRanges(c.ignored, line) || inRanges(c.ruleIgnored[rule], line)
+}
+
+func inRanges(ranges []lineRange, line int) bool {
+	for _, r := range ranges {
+		if line >= r.start && line <= r.end {
+			return true
+		}
+	}
+	return false
+}
+
+// collectSuppressions collects the rules of the suppression comments of the nodes; a suppression comment
+// in the comment above a node applies to the line of the node
+func collectSuppressions(node *yaml.Node, suppressions map[int][]string) {
+	for _, line := range strings.Split(node.HeadComment, "\n") {
+		text := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+		if !strings.HasPrefix(text, disableNextLineDirective) {
+			continue
+		}
+		rules := strings.Fields(strings.Replace(strings.TrimPrefix(text, disableNextLineDirective), ",", " ", -1))
+		if len(rules) == 0 {
+			rules = []string{anyRule}
+		}
+		suppressions[node.Line] = append(suppressions[node.Line], rules...)
+	}
+	for _, child := range node.Content {
+		collectSuppressions(child, suppressions)
+	}
+}
+
+// getPathRanges returns the lines of the nodes which match the path. The path parts are map keys or sequence items;
+// a sequence item is matched by its name or its index, and "*" matches all the keys or items.
+func getPathRanges(node *yaml.Node, path []string, start int) []lineRange {
+	if len(path) == 0 {
+		return []lineRange{{start, getLastLine(node)}}
+	}
+	var ranges []lineRange
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			ranges = append(ranges, getPathRanges(child, path, child.Line)...)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+			if path[0] == anyRule || keyNode.Value == path[0] {
+				ranges = append(ranges, getPathRanges(node.Content[i+1], path[1:], keyNode.Line)...)
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			var nameNode *yaml.Node
+			if item.Kind == yaml.MappingNode {
+				nameNode = getPropValueByName(item, nameYamlField)
+			}
+			if path[0] == anyRule || path[0] == strconv.Itoa(i) || nameNode != nil && nameNode.Value == path[0] {
+				ranges = append(ranges, getPathRanges(item, path[1:], item.Line)...)
+			}
+		}
+	}
+	return ranges
+}
+
+// getLastLine returns the last line of the node
+func getLastLine(node *yaml.Node) int {
+	line := node.Line
+	if node.Kind == yaml.ScalarNode && node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		line += strings.Count(strings.TrimSuffix(node.Value, "\n"), "\n") + 1
+	}
+	for _, child := range node.Content {
+		if childLine := getLastLine(child); childLine > line {
+			line = childLine
+		}
+	}
+	return line
+}
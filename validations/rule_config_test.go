@@ -0,0 +1,246 @@
+package validate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("Rule configuration", func() {
+	mtaContent := []byte(`ID: mta
+_schema-version: '3.2'
+version: 0.0.1
+modules:
+  - name: a
+    type: java
+    # mta-validate-disable-next-line paths
+    path: a
+  - name: b
+    type: java
+    path: b
+    build-parameters:
+      builder: custom
+resources:
+  # mta-validate-disable-next-line
+  - name: r1
+    type: org.cloudfoundry.existing-service
+  - name: r2
+    type: org.cloudfoundry.existing-service
+`)
+
+	getLines := func(issues []YamlValidationIssue) []int {
+		var lines []int
+		for _, issue := range issues {
+			lines = append(lines, issue.Line)
+		}
+		return lines
+	}
+
+	runRules := func(config string) ([]YamlValidationIssue, []YamlValidationIssue) {
+		mtaStr, err := mta.Unmarshal(mtaContent)
+		Ω(err).Should(Succeed())
+		node, err := getContentNode(mtaContent)
+		Ω(err).Should(Succeed())
+		ruleConfig, issues := parseRuleConfig([]byte(config), ruleConfigFileName)
+		Ω(issues).Should(BeEmpty())
+		return runRuleSemanticValidations(mtaStr, node, "", "", newRuleContext(ruleConfig, node, "mta.yaml", true))
+	}
+
+	It("suppresses the issues of the rules in the line after the suppression comments", func() {
+		mtaStr, err := mta.Unmarshal(mtaContent)
+		Ω(err).Should(Succeed())
+		node, err := getContentNode(mtaContent)
+		Ω(err).Should(Succeed())
+		errors, warnings := runSemanticValidations(mtaStr, node, "", "", true)
+		Ω(getLines(errors)).Should(Equal([]int{11, 13}))
+		Ω(getLines(warnings)).Should(Equal([]int{18}))
+	})
+
+	It("applies the severity of the rules", func() {
+		errors, warnings := runRules(`
+rules:
+  paths:
+    severity: "off"
+  builders:
+    severity: warn
+  unusedResources:
+    severity: error
+`)
+		Ω(getLines(errors)).Should(Equal([]int{18}))
+		Ω(getLines(warnings)).Should(Equal([]int{13}))
+	})
+
+	It("applies the strict flag of the rules", func() {
+		errors, warnings := runRules(`
+rules:
+  builders:
+    strict: false
+`)
+		Ω(getLines(errors)).Should(Equal([]int{11}))
+		Ω(getLines(warnings)).Should(Equal([]int{13, 18}))
+	})
+
+	It("ignores the issues of the ignored paths", func() {
+		errors, warnings := runRules(`
+ignore:
+  - modules.b.build-parameters
+rules:
+  paths:
+    ignore:
+      - modules.*
+  unusedResources:
+    ignore:
+      - resources.1
+`)
+		Ω(errors).Should(BeEmpty())
+		Ω(warnings).Should(BeEmpty())
+	})
+
+	It("applies the suppression comments and the configuration to the schema validations", func() {
+		content := []byte(`_schema-version: '3.2'
+# mta-validate-disable-next-line schema
+ID: mta id
+version: 0.0.1
+modules:
+  - name: a
+    type: java
+  - name: b c
+    type: java
+`)
+		errors, warnings := validateWithSchemaVersion(content, "", "", "", true, false, true, "")
+		Ω(getLines(errors)).Should(Equal([]int{8}))
+		Ω(warnings).Should(BeEmpty())
+	})
+
+	Describe("loadRuleConfig", func() {
+		var dir string
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "rules")
+			Ω(err).Should(Succeed())
+		})
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("returns nothing when the project does not have a rule configuration file", func() {
+			config, issues := loadRuleConfig(dir)
+			Ω(config).Should(BeNil())
+			Ω(issues).Should(BeEmpty())
+		})
+
+		It("reads the rule configuration file of the project", func() {
+			Ω(ioutil.WriteFile(filepath.Join(dir, ruleConfigFileName), []byte(`
+rules:
+  paths:
+    severity: "off"
+`), 0644)).Should(Succeed())
+			config, issues := loadRuleConfig(dir)
+			Ω(issues).Should(BeEmpty())
+			Ω(config.Rules[pathsValidation].Severity).Should(Equal(severityOff))
+		})
+
+		It("is used by the validation of the MTA", func() {
+			Ω(ioutil.WriteFile(filepath.Join(dir, "mta.yaml"), mtaContent, 0644)).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(dir, ruleConfigFileName), []byte(`
+rules:
+  paths:
+    severity: "off"
+  builders:
+    severity: warn
+`), 0644)).Should(Succeed())
+			warning, err := MtaYaml(dir, "mta.yaml", true, true, true, "")
+			Ω(err).Should(Succeed())
+			Ω(warning).Should(ContainSubstring("line 13: "))
+		})
+
+		It("is used by the validation of the MTA extensions", func() {
+			Ω(ioutil.WriteFile(filepath.Join(dir, "my.mtaext"), []byte(`_schema-version: '3.2'
+ID: mta ext
+extends: mta
+`), 0644)).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(dir, ruleConfigFileName), []byte(`
+rules:
+  schema:
+    severity: warn
+`), 0644)).Should(Succeed())
+			warning, err := Mtaext(dir, filepath.Join(dir, "my.mtaext"), true, false, true, "")
+			Ω(err).Should(Succeed())
+			Ω(warning).Should(ContainSubstring("line 2: "))
+		})
+
+		It("adds the types of the types definition files to the types validation", func() {
+			Ω(ioutil.WriteFile(filepath.Join(dir, "mta.yaml"), []byte(`ID: mta
+_schema-version: '3.2'
+version: 0.0.1
+modules:
+  - name: worker
+    type: com.acme.worker
+`), 0644)).Should(Succeed())
+			Ω(os.Mkdir(filepath.Join(dir, "config"), 0755)).Should(Succeed())
+			types, err := ioutil.ReadFile(getTestPath("types", "types.yaml"))
+			Ω(err).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(dir, "config", "types.yaml"), types, 0644)).Should(Succeed())
+
+			warning, err := MtaYaml(dir, "mta.yaml", true, true, true, pathsValidation)
+			Ω(err).Should(Succeed())
+			Ω(warning).Should(ContainSubstring(fmt.Sprintf(unknownTypeMsg, "com.acme.worker", "worker", moduleEntityKind)))
+
+			Ω(ioutil.WriteFile(filepath.Join(dir, ruleConfigFileName), []byte(`
+types:
+  - config/types.yaml
+`), 0644)).Should(Succeed())
+			_, err = MtaYaml(dir, "mta.yaml", true, true, true, pathsValidation)
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(missingTypeParameterMsg, "worker", moduleEntityKind, "com.acme.worker", "queue")))
+		})
+
+		It("reports the types definition files which cannot be loaded", func() {
+			configPath := filepath.Join(dir, ruleConfigFileName)
+			Ω(ioutil.WriteFile(configPath, []byte(`
+types:
+  - unknown.yaml
+`), 0644)).Should(Succeed())
+			config, issues := loadRuleConfig(dir)
+			Ω(config).Should(BeNil())
+			Ω(len(issues)).Should(Equal(1))
+			Ω(issues[0].Msg).Should(HavePrefix(fmt.Sprintf(ruleConfigTypesFailedMsg, configPath, "")))
+		})
+
+		It("reports an invalid rule configuration file", func() {
+			configPath := filepath.Join(dir, ruleConfigFileName)
+			Ω(ioutil.WriteFile(configPath, []byte(`
+rules:
+  path:
+    severity: "off"
+  names:
+    severity: info
+`), 0644)).Should(Succeed())
+			config, issues := loadRuleConfig(dir)
+			Ω(config).Should(BeNil())
+			Ω(issues).Should(Equal([]YamlValidationIssue{
+				{Msg: fmt.Sprintf(unknownSeverityMsg, "info", namesValidation, configPath)},
+				{Msg: fmt.Sprintf(unknownRuleMsg, "path", configPath, strings.Join(getRuleNames(), ", "))},
+			}))
+		})
+
+		It("reports the unknown fields of the rule configuration file", func() {
+			Ω(ioutil.WriteFile(filepath.Join(dir, ruleConfigFileName), []byte(`
+rules:
+  paths:
+    level: "off"
+`), 0644)).Should(Succeed())
+			config, issues := loadRuleConfig(dir)
+			Ω(config).Should(BeNil())
+			Ω(len(issues)).Should(Equal(1))
+			Ω(issues[0].Msg).Should(ContainSubstring("field level not found"))
+		})
+	})
+})
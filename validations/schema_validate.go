@@ -12,7 +12,12 @@ import (
 )
 
 const (
-	propertyExistsErrorMsg = `the "%s" key is not allowed inside the "%s"`
+	propertyExistsErrorMsg   = `the "%s" key is not allowed inside the "%s"`
+	minItemsErrorMsg         = `the "%s" property must have at least %d items`
+	duplicateItemErrorMsg    = `the "%s" item is a duplicate of the "%s" item`
+	constValueErrorMsg       = `the "%s" value of the "%s" property is invalid; expected "%s"`
+	noAlternativeErrorMsg    = `the "%s" property does not match any of the allowed schemas`
+	manyAlternativesErrorMsg = `the "%s" property matches more than one of the allowed schemas; it must match exactly one`
 )
 
 // YamlValidationIssue - specific issue
@@ -21,6 +26,8 @@ type YamlValidationIssue struct {
 	Msg string
 	// Line - line number indicating issue
 	Line int
+	// Fix - the optional fix of the issue
+	Fix *YamlFix
 }
 
 // YamlValidationIssues - list of issue's
@@ -430,6 +437,133 @@ func matchesEnumValues(enumValues []string) YamlCheck {
 	}
 }
 
+// Validates that the value equals the const value
+func matchesConstValue(constValue string) YamlCheck {
+	return func(yNode, yParentNode *yaml.Node, path []string) YamlValidationIssues {
+		if yNode == nil {
+			return []YamlValidationIssue{}
+		}
+
+		if yNode.Kind != yaml.ScalarNode || yNode.Value != constValue {
+			return []YamlValidationIssue{
+				{
+					Msg:  fmt.Sprintf(constValueErrorMsg, yNode.Value, buildPathString(path), constValue),
+					Line: yNode.Line,
+				},
+			}
+		}
+
+		return []YamlValidationIssue{}
+	}
+}
+
+// Validates that the array has at least the minimum number of items
+func hasMinItems(minItems int) YamlCheck {
+	return func(yNode, yParentNode *yaml.Node, path []string) YamlValidationIssues {
+		if yNode == nil || yNode.Kind != yaml.SequenceNode {
+			return []YamlValidationIssue{}
+		}
+
+		if len(yNode.Content) < minItems {
+			return []YamlValidationIssue{
+				{
+					Msg:  fmt.Sprintf(minItemsErrorMsg, buildPathString(path), minItems),
+					Line: yNode.Line,
+				},
+			}
+		}
+
+		return []YamlValidationIssue{}
+	}
+}
+
+// Validates that the items of the array are unique; the items are compared by their values
+func hasUniqueItems() YamlCheck {
+	return func(yNode, yParentNode *yaml.Node, path []string) YamlValidationIssues {
+		var issues YamlValidationIssues
+		if yNode == nil || yNode.Kind != yaml.SequenceNode {
+			return issues
+		}
+
+		itemIndexes := make(map[string]int)
+		for i, item := range yNode.Content {
+			var value interface{}
+			if err := item.Decode(&value); err != nil {
+				continue
+			}
+			key := fmt.Sprintf("%#v", value)
+			if first, ok := itemIndexes[key]; ok {
+				issues = append(issues, YamlValidationIssue{
+					Msg: fmt.Sprintf(duplicateItemErrorMsg,
+						buildPathString(append(path, fmt.Sprintf("[%d]", i))),
+						buildPathString(append(path, fmt.Sprintf("[%d]", first)))),
+					Line: item.Line,
+				})
+				continue
+			}
+			itemIndexes[key] = i
+		}
+
+		return issues
+	}
+}
+
+// DSL method to ensure a map has only the allowed keys
+func noAdditionalProperties(allowedKeys []string) YamlCheck {
+	return func(yNode, yParentNode *yaml.Node, path []string) YamlValidationIssues {
+		var issues YamlValidationIssues
+		if yNode == nil || yNode.Kind != yaml.MappingNode {
+			return issues
+		}
+
+		// Properties are listed in the Content of node as slice of key, value, key, value,...
+		for i := 0; i < len(yNode.Content); i += 2 {
+			keyNode := yNode.Content[i]
+			if !containsString(allowedKeys, keyNode.Value) {
+				issues = append(issues, YamlValidationIssue{
+					Msg:  fmt.Sprintf(propertyExistsErrorMsg, keyNode.Value, buildPathString(path)),
+					Line: keyNode.Line,
+				})
+			}
+		}
+
+		return issues
+	}
+}
+
+// DSL method to ensure a value matches the checks of at least one of the alternatives,
+// or of exactly one of them
+func matchesAlternatives(exactlyOne bool, alternatives [][]YamlCheck) YamlCheck {
+	return func(yNode, yParentNode *yaml.Node, path []string) YamlValidationIssues {
+		if yNode == nil {
+			return []YamlValidationIssue{}
+		}
+
+		matches := 0
+		for _, checks := range alternatives {
+			if len(sequence(checks...)(yNode, yParentNode, path)) == 0 {
+				matches++
+			}
+		}
+		if matches == 0 {
+			return []YamlValidationIssue{{Msg: fmt.Sprintf(noAlternativeErrorMsg, buildPathString(path)), Line: yNode.Line}}
+		}
+		if exactlyOne && matches > 1 {
+			return []YamlValidationIssue{{Msg: fmt.Sprintf(manyAlternativesErrorMsg, buildPathString(path)), Line: yNode.Line}}
+		}
+
+		return []YamlValidationIssue{}
+	}
+}
+
+// DSL method to execute the checks of a definition; the checks are dereferenced when the check is executed,
+// since they are built after the reference when the definition references itself
+func reference(checks *[]YamlCheck) YamlCheck {
+	return func(yNode, yParentNode *yaml.Node, path []string) YamlValidationIssues {
+		return sequence(*checks...)(yNode, yParentNode, path)
+	}
+}
+
 func prettifyPath(path string) string {
 	wrongIdxSyntax, _ := regexp.Compile("\\.\\[")
 
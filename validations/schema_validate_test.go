@@ -249,9 +249,9 @@ optionalClasses:
 		validateIssues := runSchemaValidations(node, validations)
 
 		Ω(validateIssues).Should(ConsistOf(
-			YamlValidationIssue{`the "oops" value of the "classes[0].room" property does not match the "^[0-9]+$" pattern`, 6},
-			YamlValidationIssue{`missing the "name" required property in the classes[1] .yaml node`, 8},
-			YamlValidationIssue{`the "optionalClasses.english" property must be a boolean`, 13},
+			YamlValidationIssue{Msg: `the "oops" value of the "classes[0].room" property does not match the "^[0-9]+$" pattern`, Line: 6},
+			YamlValidationIssue{Msg: `missing the "name" required property in the classes[1] .yaml node`, Line: 8},
+			YamlValidationIssue{Msg: `the "optionalClasses.english" property must be a boolean`, Line: 13},
 		))
 	})
 })
@@ -24,15 +24,57 @@ func buildValidationsFromSchemaText(yaml []byte) ([]YamlCheck, []YamlValidationI
 		return validations, schemaIssues
 	}
 
-	return buildValidationsFromSchema(y)
+	return buildValidationsFromSchema(y, newSchemaDefinitions(y.Get("definitions")))
+}
+
+// schemaDefinitions holds the rules of the "definitions" node of the schema, which are referenced by "$ref",
+// and the validations built from them. The validations are built once, when the definition is first referenced;
+// they are kept by pointer, so a definition can reference itself.
+type schemaDefinitions struct {
+	rules       *simpleyaml.Yaml
+	validations map[string]*[]YamlCheck
+}
+
+func newSchemaDefinitions(rules *simpleyaml.Yaml) *schemaDefinitions {
+	return &schemaDefinitions{rules: rules, validations: make(map[string]*[]YamlCheck)}
+}
+
+func (d *schemaDefinitions) get(name string) (*[]YamlCheck, []YamlValidationIssue) {
+	if validations, ok := d.validations[name]; ok {
+		return validations, nil
+	}
+	rule := d.rules.Get(name)
+	if !rule.IsFound() {
+		return nil, []YamlValidationIssue{{Msg: fmt.Sprintf(`invalid .yaml file schema: the "%s" definition does not exist`, name), Line: 0}}
+	}
+	validations := &[]YamlCheck{}
+	d.validations[name] = validations
+	var schemaIssues []YamlValidationIssue
+	*validations, schemaIssues = buildValidationsFromSchema(rule, d)
+	return validations, schemaIssues
 }
 
 // Internal YAML validation builder
 // Will be called recursively and traverse the schema structure.
-func buildValidationsFromSchema(schema *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationIssue) {
+func buildValidationsFromSchema(schema *simpleyaml.Yaml, definitions *schemaDefinitions) ([]YamlCheck, []YamlValidationIssue) {
 	var validations []YamlCheck
 	var schemaIssues []YamlValidationIssue
 
+	// $ref: name
+	if schema.Get("$ref").IsFound() {
+		return buildRefValidation(schema, definitions)
+	}
+	// oneOf:
+	// - type: map
+	//   ...
+	// - enum: [...]
+	if schema.Get("oneOf").IsFound() {
+		return buildAlternativesValidation(schema, "oneOf", definitions)
+	}
+	if schema.Get("anyOf").IsFound() {
+		return buildAlternativesValidation(schema, "anyOf", definitions)
+	}
+
 	typeNode := schema.Get("type")
 	typeNodeValue, _ := typeNode.String()
 	if typeNode.IsFound() && (typeNodeValue == "map" || typeNodeValue == "seq") {
@@ -46,8 +88,11 @@ func buildValidationsFromSchema(schema *simpleyaml.Yaml) ([]YamlCheck, []YamlVal
 				schemaIssues = appendIssue(schemaIssues, "invalid .yaml file schema: the mapping node must be a map", 0)
 				return validations, schemaIssues
 			}
-			mappingValidations, mappingSchemaIssues := buildValidationsForMapping(mappingNode)
+			mappingValidations, mappingSchemaIssues := buildValidationsForMapping(mappingNode, definitions)
 			schemaIssues = append(schemaIssues, mappingSchemaIssues...)
+			additionalValidations, additionalSchemaIssues := buildAdditionalPropertiesValidation(schema, mappingNode)
+			mappingValidations = append(mappingValidations, additionalValidations...)
+			schemaIssues = append(schemaIssues, additionalSchemaIssues...)
 			// Only perform validations on the mapping value when it isn't null, unless specified as required
 			mappingValidations, schemaIssues = buildOptionalOrRequiredValidation(schema, mappingValidations, schemaIssues)
 			validations = append(validations, mappingValidations...)
@@ -73,7 +118,9 @@ func buildValidationsFromSchema(schema *simpleyaml.Yaml) ([]YamlCheck, []YamlVal
 
 			// A sequence schema node has exactly 1 element
 			sequenceItemNode := sequenceNode.GetIndex(0)
-			sequenceValidations, newSchemaIssues := buildValidationsFromSequence(sequenceItemNode)
+			arrayValidations, newSchemaIssues := buildArrayValidations(schema)
+			schemaIssues = append(schemaIssues, newSchemaIssues...)
+			sequenceValidations, newSchemaIssues := buildValidationsFromSequence(sequenceItemNode, definitions, arrayValidations...)
 			schemaIssues = append(schemaIssues, newSchemaIssues...)
 			// Only perform validations on the sequence values when they aren't null, unless specified as required
 			sequenceValidations, schemaIssues = buildOptionalOrRequiredValidation(schema, sequenceValidations, schemaIssues)
@@ -87,7 +134,7 @@ func buildValidationsFromSchema(schema *simpleyaml.Yaml) ([]YamlCheck, []YamlVal
 	return validations, schemaIssues
 }
 
-func buildValidationsForMapping(mappingNode *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationIssue) {
+func buildValidationsForMapping(mappingNode *simpleyaml.Yaml, definitions *schemaDefinitions) ([]YamlCheck, []YamlValidationIssue) {
 	var validations []YamlCheck
 	var schemaIssues []YamlValidationIssue
 
@@ -98,7 +145,7 @@ func buildValidationsForMapping(mappingNode *simpleyaml.Yaml) ([]YamlCheck, []Ya
 		//   =:
 		//     type: ...
 		value := mappingNode.Get(keys[0])
-		propInnerValidations, newSchemaIssues := buildValidationsFromSchema(value)
+		propInnerValidations, newSchemaIssues := buildValidationsFromSchema(value, definitions)
 		propWrapperValidation := forEachProperty(propInnerValidations...)
 		newValidations := append(validations, propWrapperValidation)
 
@@ -109,7 +156,7 @@ func buildValidationsForMapping(mappingNode *simpleyaml.Yaml) ([]YamlCheck, []Ya
 		// mapping:
 		//   firstName:  {required: true}
 		//   ...
-		newValidations, newSchemaIssues := buildValidationsFromMap(mappingNode)
+		newValidations, newSchemaIssues := buildValidationsFromMap(mappingNode, definitions)
 		schemaIssues = append(schemaIssues, newSchemaIssues...)
 		validations = append(validations, newValidations...)
 	}
@@ -118,7 +165,7 @@ func buildValidationsForMapping(mappingNode *simpleyaml.Yaml) ([]YamlCheck, []Ya
 
 // Create Validations for a mapping
 // each key's inner validations will be wrapping in a "property" validation
-func buildValidationsFromMap(y *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationIssue) {
+func buildValidationsFromMap(y *simpleyaml.Yaml, definitions *schemaDefinitions) ([]YamlCheck, []YamlValidationIssue) {
 	var validations []YamlCheck
 	var schemaIssues []YamlValidationIssue
 
@@ -127,7 +174,7 @@ func buildValidationsFromMap(y *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationI
 	keys, _ := y.GetMapKeys()
 	for _, key := range keys {
 		value := y.Get(key)
-		propInnerValidations, newSchemaIssues := buildValidationsFromSchema(value)
+		propInnerValidations, newSchemaIssues := buildValidationsFromSchema(value, definitions)
 		schemaIssues = append(schemaIssues, newSchemaIssues...)
 		propWrapperValidation := property(key, propInnerValidations...)
 		validations = append(validations, propWrapperValidation)
@@ -137,12 +184,12 @@ func buildValidationsFromMap(y *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationI
 
 // Creates validations for a sequence
 // Will wrap the nested checks with a "typeIsArray" check and iterate over the elements
-// using "forEach"
-func buildValidationsFromSequence(y *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationIssue) {
+// using "forEach"; the array checks are performed on the whole sequence
+func buildValidationsFromSequence(y *simpleyaml.Yaml, definitions *schemaDefinitions, arrayChecks ...YamlCheck) ([]YamlCheck, []YamlValidationIssue) {
 	var validations []YamlCheck
 
-	sequenceInnerValidations, newIssues := buildValidationsFromSchema(y)
-	seqChecksWrapper := sequenceFailFast(typeIsArray(), forEach(sequenceInnerValidations...))
+	sequenceInnerValidations, newIssues := buildValidationsFromSchema(y, definitions)
+	seqChecksWrapper := sequenceFailFast(typeIsArray(), sequence(append(arrayChecks, forEach(sequenceInnerValidations...))...))
 	validations = append(validations, seqChecksWrapper)
 
 	return validations, newIssues
@@ -159,6 +206,8 @@ func buildLeafValidations(y *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationIssu
 
 	validations, schemaIssues = invokeLeafValidation(y, validations, schemaIssues, buildPatternValidation)
 
+	validations, schemaIssues = invokeLeafValidation(y, validations, schemaIssues, buildConstValidation)
+
 	// Special handling is needed for "optional" and "required" Validations, must be invoked last
 	// and receive all previous built validations as extra wrapping will be done here.
 	return buildOptionalOrRequiredValidation(y, validations, schemaIssues)
@@ -218,7 +267,7 @@ func buildTypeValidation(y *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationIssue
 
 func buildEnumValidation(enumNode *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationIssue) {
 	if !enumNode.IsArray() {
-		return []YamlCheck{}, []YamlValidationIssue{{"invalid .yaml file schema: enums values must be listed as an array", 0}}
+		return []YamlCheck{}, []YamlValidationIssue{{Msg: "invalid .yaml file schema: enums values must be listed as an array", Line: 0}}
 	}
 
 	enumsNumber, _ := enumNode.GetArraySize()
@@ -227,7 +276,7 @@ func buildEnumValidation(enumNode *simpleyaml.Yaml) ([]YamlCheck, []YamlValidati
 	for i := 0; i < enumsNumber; i++ {
 		enumValueNode := enumNode.GetIndex(i)
 		if enumValueNode.IsArray() || enumValueNode.IsMap() {
-			return []YamlCheck{}, []YamlValidationIssue{{"invalid .yaml file schema: enum values must be simple", 0}}
+			return []YamlCheck{}, []YamlValidationIssue{{Msg: "invalid .yaml file schema: enum values must be simple", Line: 0}}
 		}
 		enumValue := getLiteralStringValue(enumValueNode)
 		enumValues = append(enumValues, enumValue)
@@ -262,6 +311,100 @@ func buildPatternValidation(y *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationIs
 	return validations, schemaIssues
 }
 
+func buildConstValidation(y *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationIssue) {
+	constNode := y.Get("const")
+	if !constNode.IsFound() {
+		return nil, nil
+	}
+	if constNode.IsArray() || constNode.IsMap() {
+		return nil, []YamlValidationIssue{{Msg: "invalid .yaml file schema: the const value must be simple", Line: 0}}
+	}
+	return []YamlCheck{matchesConstValue(getLiteralStringValue(constNode))}, nil
+}
+
+// Creates the checks of the whole sequence
+// e.g: {type: seq, minItems: 1, uniqueItems: true, sequence: [...]}
+func buildArrayValidations(y *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationIssue) {
+	var validations []YamlCheck
+	var schemaIssues []YamlValidationIssue
+
+	minItemsNode := y.Get("minItems")
+	if minItemsNode.IsFound() {
+		minItems, err := minItemsNode.Int()
+		if err != nil || minItems < 0 {
+			schemaIssues = appendIssue(schemaIssues, "invalid .yaml file schema: the minItems node must be a non-negative integer", 0)
+		} else {
+			validations = append(validations, hasMinItems(minItems))
+		}
+	}
+
+	uniqueItemsNode := y.Get("uniqueItems")
+	if uniqueItemsNode.IsFound() {
+		uniqueItems, err := uniqueItemsNode.Bool()
+		if err != nil {
+			schemaIssues = appendIssue(schemaIssues, "invalid .yaml file schema: the uniqueItems node must be a boolean", 0)
+		} else if uniqueItems {
+			validations = append(validations, hasUniqueItems())
+		}
+	}
+	return validations, schemaIssues
+}
+
+// Creates the check of the keys of a mapping, when other keys than the mapped keys are not allowed
+// e.g: {type: map, additionalProperties: false, mapping: {...}}
+func buildAdditionalPropertiesValidation(y *simpleyaml.Yaml, mappingNode *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationIssue) {
+	additionalNode := y.Get("additionalProperties")
+	if !additionalNode.IsFound() {
+		return nil, nil
+	}
+	additional, err := additionalNode.Bool()
+	if err != nil {
+		return nil, []YamlValidationIssue{{Msg: "invalid .yaml file schema: the additionalProperties node must be a boolean", Line: 0}}
+	}
+	if additional {
+		return nil, nil
+	}
+	keys, _ := mappingNode.GetMapKeys()
+	// The default rule allows any key
+	if len(keys) == 1 && keys[0] == "=" {
+		return nil, nil
+	}
+	return []YamlCheck{noAdditionalProperties(keys)}, nil
+}
+
+// Creates the check of a reference to a rule of the "definitions" node of the schema
+// e.g: {$ref: "#/definitions/parameters"}
+func buildRefValidation(y *simpleyaml.Yaml, definitions *schemaDefinitions) ([]YamlCheck, []YamlValidationIssue) {
+	ref, err := y.Get("$ref").String()
+	if err != nil {
+		return nil, []YamlValidationIssue{{Msg: "invalid .yaml file schema: the $ref node must be a string", Line: 0}}
+	}
+	refValidations, schemaIssues := definitions.get(strings.TrimPrefix(ref, "#/definitions/"))
+	if refValidations == nil {
+		return nil, schemaIssues
+	}
+	return buildOptionalOrRequiredValidation(y, []YamlCheck{reference(refValidations)}, schemaIssues)
+}
+
+// Creates the check of the alternative rules: a value must match at least one of the "anyOf" rules,
+// and exactly one of the "oneOf" rules
+func buildAlternativesValidation(y *simpleyaml.Yaml, keyword string, definitions *schemaDefinitions) ([]YamlCheck, []YamlValidationIssue) {
+	var schemaIssues []YamlValidationIssue
+
+	alternativesNode := y.Get(keyword)
+	size, err := alternativesNode.GetArraySize()
+	if err != nil || size == 0 {
+		return nil, []YamlValidationIssue{{Msg: fmt.Sprintf("invalid .yaml file schema: the %s node must be a non-empty array", keyword), Line: 0}}
+	}
+	var alternatives [][]YamlCheck
+	for i := 0; i < size; i++ {
+		alternativeValidations, newSchemaIssues := buildValidationsFromSchema(alternativesNode.GetIndex(i), definitions)
+		schemaIssues = append(schemaIssues, newSchemaIssues...)
+		alternatives = append(alternatives, alternativeValidations)
+	}
+	return buildOptionalOrRequiredValidation(y, []YamlCheck{matchesAlternatives(keyword == "oneOf", alternatives)}, schemaIssues)
+}
+
 // Utility to reduce verbosity
 func invokeLeafValidation(y *simpleyaml.Yaml, validations []YamlCheck, schemaIsssues []YamlValidationIssue,
 	leafBuilder func(y *simpleyaml.Yaml) ([]YamlCheck, []YamlValidationIssue)) ([]YamlCheck, []YamlValidationIssue) {
@@ -80,6 +80,49 @@ type: str
 enum:
   [duck, [dog, cat]]
 `, `invalid .yaml file schema: enum values must be simple`),
+
+		Entry("additionalProperties not bool", `
+type: map
+additionalProperties: abc
+mapping:
+  firstName:  {required: true}
+`, `invalid .yaml file schema: the additionalProperties node must be a boolean`),
+
+		Entry("minItems not an integer", `
+type: seq
+minItems: abc
+sequence:
+- type: str
+`, `invalid .yaml file schema: the minItems node must be a non-negative integer`),
+
+		Entry("uniqueItems not bool", `
+type: seq
+uniqueItems: 1
+sequence:
+- type: str
+`, `invalid .yaml file schema: the uniqueItems node must be a boolean`),
+
+		Entry("const not simple", `
+const: [a, b]
+`, `invalid .yaml file schema: the const value must be simple`),
+
+		Entry("oneOf not an array", `
+oneOf: abc
+`, `invalid .yaml file schema: the oneOf node must be a non-empty array`),
+
+		Entry("anyOf empty", `
+anyOf: []
+`, `invalid .yaml file schema: the anyOf node must be a non-empty array`),
+
+		Entry("$ref not string", `
+$ref: [a]
+`, `invalid .yaml file schema: the $ref node must be a string`),
+
+		Entry("$ref to a missing definition", `
+type: map
+mapping:
+  name: {$ref: "#/definitions/name"}
+`, `invalid .yaml file schema: the "name" definition does not exist`),
 	)
 
 	var _ = DescribeTable("Valid input",
@@ -206,6 +249,90 @@ mapping:
 `, `
 firstName: Tim
 isHappy: false
+`),
+		Entry("additionalProperties false", `
+type: map
+additionalProperties: false
+mapping:
+  firstName: {required: true}
+  lastName:
+`, `
+firstName: Donald
+lastName: duck
+`),
+		Entry("additionalProperties false with default rule", `
+type: map
+additionalProperties: false
+mapping:
+  =: {type: any}
+`, `
+firstName: Donald
+`),
+		Entry("minItems and uniqueItems", `
+type: seq
+minItems: 2
+uniqueItems: true
+sequence:
+- type: any
+`, `
+- a
+- {b: 1}
+- {b: 2}
+`),
+		Entry("const", `
+type: map
+mapping:
+  kind: {const: duck}
+  happy: {type: bool, const: true}
+`, `
+kind: duck
+happy: true
+`),
+		Entry("anyOf", `
+type: map
+mapping:
+  animal:
+    anyOf:
+    - enum: [duck, dog]
+    - pattern: '/^d/'
+`, `
+animal: duck
+`),
+		Entry("oneOf", `
+type: map
+mapping:
+  includes:
+    oneOf:
+    - type: map
+      mapping:
+        name: {required: true}
+    - type: seq
+      sequence:
+      - type: map
+        mapping:
+          name: {required: true}
+`, `
+includes:
+- name: a
+`),
+		Entry("$ref to a recursive definition", `
+definitions:
+  node:
+    type: map
+    additionalProperties: false
+    mapping:
+      name: {required: true}
+      children:
+        type: seq
+        sequence:
+        - {$ref: "#/definitions/node"}
+$ref: node
+`, `
+name: a
+children:
+- name: b
+  children:
+  - name: c
 `),
 	)
 
@@ -328,5 +455,102 @@ mapping:
 firstName: John
 isHappy: 123
 `, `the "root.isHappy" property must be a boolean`, 3),
+
+		Entry("additionalProperties false", `
+type: map
+additionalProperties: false
+mapping:
+  firstName: {required: true}
+`, `
+firstName: Donald
+lastName: duck
+`, `the "lastName" key is not allowed inside the "root"`, 3),
+
+		Entry("minItems", `
+type: map
+mapping:
+  names:
+    type: seq
+    minItems: 2
+    sequence:
+    - type: str
+`, `
+names:
+  - Donald
+`, `the "root.names" property must have at least 2 items`, 3),
+
+		Entry("uniqueItems", `
+type: map
+mapping:
+  names:
+    type: seq
+    uniqueItems: true
+    sequence:
+    - type: any
+`, `
+names:
+  - {name: Donald}
+  - Bugs
+  - {name: Donald}
+`, `the "names[2]" item is a duplicate of the "names[0]" item`, 5),
+
+		Entry("const", `
+type: map
+mapping:
+  kind: {const: duck}
+`, `
+kind: dog
+`, `the "dog" value of the "root.kind" property is invalid; expected "duck"`, 2),
+
+		Entry("anyOf", `
+type: map
+mapping:
+  animal:
+    anyOf:
+    - enum: [duck, dog]
+    - pattern: '/^d/'
+`, `
+animal: cat
+`, `the "root.animal" property does not match any of the allowed schemas`, 2),
+
+		Entry("oneOf matches more than one", `
+type: map
+mapping:
+  animal:
+    oneOf:
+    - enum: [duck, dog]
+    - pattern: '/^d/'
+`, `
+animal: duck
+`, `the "root.animal" property matches more than one of the allowed schemas; it must match exactly one`, 2),
+
+		Entry("required $ref", `
+definitions:
+  name: {pattern: '/^[a-z]+$/'}
+type: map
+mapping:
+  name: {$ref: "#/definitions/name", required: true}
+`, `
+lastName: duck
+`, `missing the "name" required property in the root .yaml node`, 2),
+
+		Entry("$ref to a recursive definition", `
+definitions:
+  node:
+    type: map
+    mapping:
+      name: {pattern: '/^[a-z]+$/'}
+      children:
+        type: seq
+        sequence:
+        - {$ref: node}
+$ref: node
+`, `
+name: a
+children:
+- name: b
+  children:
+  - name: C
+`, `the "C" value of the "children[0].children[0].name" property does not match the "^[a-z]+$" pattern`, 6),
 	)
 })
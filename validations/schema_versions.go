@@ -0,0 +1,178 @@
+package validate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	unsupportedSchemaVersionMsg = `the "%s" schema version is not supported; use one of the following versions: %s`
+	schemaVersionFeatureMsg     = `the "%s" field is supported from the "%s" schema version; the MTA uses the "%s" schema version`
+
+	schemaVersionYamlField = "_schema-version"
+	moduleTypesYamlField   = "module-types"
+	resourceTypesYamlField = "resource-types"
+
+	// The schema version used when the MTA does not declare a valid one
+	defaultSchemaMajor = 3
+	defaultSchemaMinor = 2
+)
+
+// schemaVersion is a supported version of the MTA schema, with its definition
+type schemaVersion struct {
+	major int
+	minor int
+	def   *[]byte
+}
+
+func (v schemaVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+// isBefore returns true if the schema version is older than the major.minor version
+func (v schemaVersion) isBefore(major, minor int) bool {
+	return v.major < major || v.major == major && v.minor < minor
+}
+
+// The supported schema versions, ordered from the oldest
+var schemaVersions = []schemaVersion{
+	{2, 1, &schemaV21Def},
+	{3, 1, &schemaV31Def},
+	{3, 2, &schemaDef},
+	{3, 3, &schemaV33Def},
+}
+
+// getSchemaVersion returns the supported schema version which matches the version, following the semantic versioning
+// rules: the major versions must be equal, and a descriptor authored for a minor version can be validated with a newer
+// minor version, so the closest supported minor version which is not older is selected. The minor and patch versions
+// can be omitted; the latest supported minor version is selected when the minor version is omitted.
+// The patch version is ignored, since it does not change the schema.
+func getSchemaVersion(version string) (schemaVersion, bool) {
+	parts := strings.Split(strings.TrimSpace(version), ".")
+	if len(parts) > 3 {
+		return schemaVersion{}, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return schemaVersion{}, false
+	}
+	minor := -1
+	if len(parts) > 1 {
+		if minor, err = strconv.Atoi(parts[1]); err != nil {
+			return schemaVersion{}, false
+		}
+	}
+	if len(parts) > 2 && len(parts[2]) == 0 {
+		return schemaVersion{}, false
+	}
+
+	result, found := schemaVersion{}, false
+	for _, v := range schemaVersions {
+		if v.major != major || v.minor < minor {
+			continue
+		}
+		if minor >= 0 {
+			return v, true
+		}
+		result, found = v, true
+	}
+	return result, found
+}
+
+func getDefaultSchemaVersion() schemaVersion {
+	v, _ := getSchemaVersion(fmt.Sprintf("%d.%d", defaultSchemaMajor, defaultSchemaMinor))
+	return v
+}
+
+func getSupportedSchemaVersions() string {
+	versions := make([]string, len(schemaVersions))
+	for i, v := range schemaVersions {
+		versions[i] = v.String()
+	}
+	return strings.Join(versions, ", ")
+}
+
+// selectSchemaVersion returns the schema version used to validate the MTA: the override version if it is not empty,
+// or the "_schema-version" of the MTA. If the version is not supported, an issue is returned
+// with the default schema version. A missing "_schema-version" is reported by the schema.
+func selectSchemaVersion(mtaNode *yaml.Node, override string) (schemaVersion, []YamlValidationIssue) {
+	version, line := override, 0
+	if len(version) == 0 {
+		versionNode := getPropValueByName(mtaNode, schemaVersionYamlField)
+		if versionNode == nil || len(versionNode.Value) == 0 {
+			return getDefaultSchemaVersion(), nil
+		}
+		version, line = versionNode.Value, versionNode.Line
+	}
+	v, ok := getSchemaVersion(version)
+	if !ok {
+		return getDefaultSchemaVersion(), []YamlValidationIssue{
+			{Msg: fmt.Sprintf(unsupportedSchemaVersionMsg, version, getSupportedSchemaVersions()), Line: line}}
+	}
+	return v, nil
+}
+
+// schemaVersionFeature is a field which is only supported from a schema version
+type schemaVersionFeature struct {
+	field string
+	major int
+	minor int
+}
+
+var (
+	metadataFeatures = []schemaVersionFeature{
+		{propertiesMetadataField, 3, 1},
+		{parametersMetadataField, 3, 1},
+	}
+	rootFeatures = append([]schemaVersionFeature{
+		{moduleTypesYamlField, 3, 2},
+		{resourceTypesYamlField, 3, 2},
+	}, metadataFeatures...)
+	moduleFeatures = append([]schemaVersionFeature{
+		{deployedAfterYamlField, 3, 2},
+		{hooksYamlField, 3, 2},
+	}, metadataFeatures...)
+	resourceFeatures = append([]schemaVersionFeature{
+		{requiresYamlField, 3, 1},
+	}, metadataFeatures...)
+)
+
+// checkSchemaVersionFeatures checks that the MTA does not use fields which are not supported by its schema version
+func checkSchemaVersionFeatures(mtaNode *yaml.Node, version schemaVersion) []YamlValidationIssue {
+	issues := checkFeatures(mtaNode, version, rootFeatures)
+	for _, moduleNode := range getPropContent(mtaNode, modulesYamlField) {
+		issues = append(issues, checkFeatures(moduleNode, version, moduleFeatures)...)
+		for _, providesNode := range getPropContent(moduleNode, providesYamlField) {
+			issues = append(issues, checkFeatures(providesNode, version, metadataFeatures)...)
+		}
+		for _, requiresNode := range getPropContent(moduleNode, requiresYamlField) {
+			issues = append(issues, checkFeatures(requiresNode, version, metadataFeatures)...)
+		}
+	}
+	for _, resourceNode := range getPropContent(mtaNode, resourcesYamlField) {
+		issues = append(issues, checkFeatures(resourceNode, version, resourceFeatures)...)
+		for _, requiresNode := range getPropContent(resourceNode, requiresYamlField) {
+			issues = append(issues, checkFeatures(requiresNode, version, metadataFeatures)...)
+		}
+	}
+	return issues
+}
+
+func checkFeatures(node *yaml.Node, version schemaVersion, features []schemaVersionFeature) []YamlValidationIssue {
+	var issues []YamlValidationIssue
+	for _, feature := range features {
+		if !version.isBefore(feature.major, feature.minor) {
+			continue
+		}
+		if keyNode := getPropByName(node, feature.field); keyNode != nil {
+			issues = append(issues, YamlValidationIssue{
+				Msg:  fmt.Sprintf(schemaVersionFeatureMsg, feature.field, fmt.Sprintf("%d.%d", feature.major, feature.minor), version),
+				Line: keyNode.Line,
+			})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,98 @@
+package validate
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SchemaVersions", func() {
+	DescribeTable("getSchemaVersion", func(version string, expected string, expectedFound bool) {
+		v, found := getSchemaVersion(version)
+		Ω(found).Should(Equal(expectedFound))
+		if found {
+			Ω(v.String()).Should(Equal(expected))
+		}
+	},
+		Entry("exact version", "3.1", "3.1", true),
+		Entry("patch version", "3.2.1", "3.2", true),
+		Entry("major version only", "3", "3.3", true),
+		Entry("older minor version", "2.0.0", "2.1", true),
+		Entry("closest newer minor version", "3.0", "3.1", true),
+		Entry("newer minor version", "3.4", "", false),
+		Entry("unknown major version", "1.0", "", false),
+		Entry("not a version", "latest", "", false),
+		Entry("empty patch version", "3.1.", "", false),
+		Entry("too many parts", "3.1.0.1", "", false),
+	)
+
+	It("validates with the schema of the MTA schema version", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '2.1'
+version: 0.0.1
+
+parameters-metadata:
+  a: {optional: true}
+parameters:
+  a: 1
+modules:
+- name: ui5app
+  type: html5
+  deployed-after: []
+  hooks:
+  - name: hook
+  properties-metadata:
+    b: {optional: true}
+  properties:
+    b: 1
+resources:
+- name: res
+  type: custom
+  requires:
+  - name: ui5app
+`)
+		err, warn := validate(mtaContent, getTestPath("mtahtml5"), true, false, false, "")
+		Ω(err).Should(BeEmpty())
+		Ω(warn).Should(ConsistOf(
+			YamlValidationIssue{Msg: `the "parameters-metadata" field is supported from the "3.1" schema version; the MTA uses the "2.1" schema version`, Line: 6},
+			YamlValidationIssue{Msg: `the "deployed-after" field is supported from the "3.2" schema version; the MTA uses the "2.1" schema version`, Line: 13},
+			YamlValidationIssue{Msg: `the "hooks" field is supported from the "3.2" schema version; the MTA uses the "2.1" schema version`, Line: 14},
+			YamlValidationIssue{Msg: `the "properties-metadata" field is supported from the "3.1" schema version; the MTA uses the "2.1" schema version`, Line: 16},
+			YamlValidationIssue{Msg: `the "requires" field is supported from the "3.1" schema version; the MTA uses the "2.1" schema version`, Line: 23},
+		))
+
+		err, warn = validateWithSchemaVersion(mtaContent, getTestPath("mtahtml5"), "mta.yaml", "3.2", true, false, false, "")
+		Ω(err).Should(BeEmpty())
+		Ω(warn).Should(BeEmpty())
+	})
+
+	It("gives an error on an unsupported schema version", func() {
+		err, _ := validate([]byte(`
+ID: mtahtml5
+_schema-version: '4.0'
+version: 0.0.1
+`), getTestPath("mtahtml5"), true, false, true, "")
+		Ω(err).Should(ConsistOf(
+			YamlValidationIssue{Msg: `the "4.0" schema version is not supported; use one of the following versions: 2.1, 3.1, 3.2, 3.3`, Line: 3},
+		))
+	})
+
+	It("gives an error on an unsupported override schema version", func() {
+		err, _ := validateWithSchemaVersion([]byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+`), getTestPath("mtahtml5"), "mta.yaml", "2", true, false, true, "")
+		Ω(err).Should(BeEmpty())
+
+		err, _ = validateWithSchemaVersion([]byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+`), getTestPath("mtahtml5"), "mta.yaml", "5", true, false, true, "")
+		Ω(err).Should(ConsistOf(
+			YamlValidationIssue{Msg: `the "5" schema version is not supported; use one of the following versions: 2.1, 3.1, 3.2, 3.3`, Line: 0},
+		))
+	})
+})
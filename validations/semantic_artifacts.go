@@ -58,6 +58,7 @@ func ifNoSource(module *mta.Module, modulesNode *yaml.Node, index int) (bool, *Y
 		return false, &YamlValidationIssue{
 			Msg:  `the "no-source" build parameter must be a boolean`,
 			Line: noSourceNode.Line,
+			Fix:  getNoSourceFix(noSourceNode),
 		}
 	}
 	return false, nil
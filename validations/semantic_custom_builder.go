@@ -25,14 +25,17 @@ func checkProjectBuilders(builders []mta.ProjectBuilder, mtaNode *yaml.Node, fie
 		builder := builderStr.Builder
 		commandsDefined := builderStr.Commands != nil
 		commandsNode := getPropValueByName(buildersNodes[i], commandsYamlField)
-		issues = append(issues, checkCustomBuilder(builder, commandsDefined, buildersNodes[i], commandsNode)...)
+		issues = append(issues, checkCustomBuilder(builder, commandsDefined, buildersNodes[i], commandsNode, buildersNodes[i])...)
 	}
 	return issues
 }
 
-func checkCustomBuilder(builder string, commandsDefined bool, builderNode *yaml.Node, commandsNode *yaml.Node) []YamlValidationIssue {
+// checkCustomBuilder checks that the "commands" are defined for the "custom" builder only; the builder parameters node
+// is the node which contains the "builder" and the "commands"
+func checkCustomBuilder(builder string, commandsDefined bool, builderNode *yaml.Node, commandsNode *yaml.Node, builderParamsNode *yaml.Node) []YamlValidationIssue {
 	if builder == customBuilder && !commandsDefined {
-		return []YamlValidationIssue{{Msg: `the "commands" property is missing in the "custom" builder`, Line: builderNode.Line}}
+		return []YamlValidationIssue{{Msg: `the "commands" property is missing in the "custom" builder`, Line: builderNode.Line,
+			Fix: getMissingCommandsFix(builderParamsNode)}}
 	} else if builder != customBuilder && commandsDefined {
 		return []YamlValidationIssue{{Msg: fmt.Sprintf(`the "commands" property is not supported by the "%s" builder`, builder), Line: commandsNode.Line}}
 	}
@@ -60,7 +63,7 @@ func checkBuildersSemantic(mta *mta.MTA, mtaNode *yaml.Node, source string, stri
 			builderNode := getPropValueByName(buildParamsNode, builderYamlField)
 			commandsDefined := module.BuildParams[commandsYamlField] != nil
 			commandsNode := getPropValueByName(buildParamsNode, commandsYamlField)
-			issues = append(issues, checkCustomBuilder(builder, commandsDefined, builderNode, commandsNode)...)
+			issues = append(issues, checkCustomBuilder(builder, commandsDefined, builderNode, commandsNode, buildParamsNode)...)
 		}
 	}
 
@@ -0,0 +1,24 @@
+package validate
+
+import (
+	"gopkg.in/yaml.v3"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+// checkDatatypes checks that the values of the properties and parameters match the datatypes
+// declared in their metadata
+func checkDatatypes(mta *mta.MTA, mtaNode *yaml.Node, source string, strict bool) (errors []YamlValidationIssue, warnings []YamlValidationIssue) {
+	return validateMetadata(mta, mtaNode, source, checkMetadataDatatypes), nil
+}
+
+func checkMetadataDatatypes(m map[string]interface{}, metadata map[string]mta.MetaData, parentNode *yaml.Node, mapType int) []YamlValidationIssue {
+	var issues []YamlValidationIssue
+	mapNode := getPropValueByName(parentNode, mapTypes[mapType].mapNodeName)
+	for key, value := range m {
+		if err := mta.CheckDatatype(key, metadata, value); err != nil {
+			issues = append(issues, YamlValidationIssue{Msg: err.Error(), Line: getPropValueByName(mapNode, key).Line})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,103 @@
+package validate
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("SemanticDatatypes", func() {
+	It("gives errors on values which do not match their datatypes", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+ - name: ui5app
+   type: html5
+   properties:
+     name: 1
+     port: "8080"
+     ratio: 1
+     debug: "yes"
+     url: ${default-url}
+     timeout: ~{srv_api/timeout}
+     unset:
+   properties-metadata:
+     name: {datatype: str}
+     port: {datatype: int}
+     ratio: {datatype: float}
+     debug: {datatype: bool}
+     url: {datatype: int}
+     timeout: {datatype: int}
+     unset: {datatype: bool}
+   provides:
+   - name: ui_api
+     properties:
+       enabled: true
+       retries: 1.5
+     properties-metadata:
+       enabled: {datatype: bool}
+       retries: {datatype: int}
+   requires:
+   - name: srv_api
+     properties:
+       size: big
+     properties-metadata:
+       size: {datatype: float}
+   hooks:
+   - name: hook1
+     requires:
+     - name: db
+       properties:
+         schema: true
+       properties-metadata:
+         schema: {datatype: str}
+
+resources:
+ - name: db
+   type: com.sap.xs.hdi-container
+   properties:
+     pool: 10
+     secure: 1
+   properties-metadata:
+     pool: {datatype: int}
+     secure: {datatype: bool}
+`)
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		errors, warnings := checkDatatypes(mta, node, "", true)
+		Ω(warnings).Should(BeNil())
+		Ω(errors).Should(ConsistOf(
+			YamlValidationIssue{Msg: `the value of the "name" field does not match its "str" datatype`, Line: 10},
+			YamlValidationIssue{Msg: `the value of the "port" field does not match its "int" datatype`, Line: 11},
+			YamlValidationIssue{Msg: `the value of the "debug" field does not match its "bool" datatype`, Line: 13},
+			YamlValidationIssue{Msg: `the value of the "retries" field does not match its "int" datatype`, Line: 29},
+			YamlValidationIssue{Msg: `the value of the "size" field does not match its "float" datatype`, Line: 36},
+			YamlValidationIssue{Msg: `the value of the "schema" field does not match its "str" datatype`, Line: 44},
+			YamlValidationIssue{Msg: `the value of the "secure" field does not match its "bool" datatype`, Line: 53},
+		))
+	})
+
+	It("is excluded by the exclude list", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+ - name: ui5app
+   type: html5
+   properties:
+     port: "8080"
+   properties-metadata:
+     port: {datatype: int}
+`)
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		errors, _ := runSemanticValidations(mta, node, "", "paths,datatypes", true)
+		Ω(errors).Should(BeEmpty())
+	})
+})
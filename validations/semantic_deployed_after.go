@@ -0,0 +1,40 @@
+package validate
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	deployedAfterWithoutParallelMsg = `the "deployed-after" property of the "%s" module is ignored because the "%s" parameter is not set to true`
+)
+
+// checkDeployedAfter checks that the modules in "deployed-after" are defined, that a module is not deployed after
+// itself and that the "deployed-after" dependencies do not contain cycles.
+// It warns when "deployed-after" is used while parallel deployment is not enabled, because it is ignored in this case.
+func checkDeployedAfter(mta *mta.MTA, mtaNode *yaml.Node, source string, strict bool) ([]YamlValidationIssue, []YamlValidationIssue) {
+	var errors []YamlValidationIssue
+	var warnings []YamlValidationIssue
+
+	modulesNode := getPropContent(mtaNode, modulesYamlField)
+
+	// The modules with the same name are reported by the names validation
+	for _, orderIssue := range mta.GetDeployedAfterIssues() {
+		deployedAfterNode := getPropContent(modulesNode[orderIssue.Module], deployedAfterYamlField)
+		errors = appendIssue(errors, orderIssue.Msg, deployedAfterNode[orderIssue.DeployedAfter].Line)
+	}
+
+	if !mta.IsParallelDeploymentEnabled() {
+		for i, module := range mta.Modules {
+			deployedAfterKeyNode := getPropByName(modulesNode[i], deployedAfterYamlField)
+			if deployedAfterKeyNode != nil {
+				warnings = appendIssue(warnings,
+					fmt.Sprintf(deployedAfterWithoutParallelMsg, module.Name, enableParallelDeploymentsYamlField), deployedAfterKeyNode.Line)
+			}
+		}
+	}
+
+	return errors, warnings
+}
@@ -0,0 +1,109 @@
+package validate
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("SemanticDeployedAfter", func() {
+	It("Sanity", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+parameters:
+  enable-parallel-deployments: true
+
+modules:
+ - name: ui5app1
+   type: html5
+   deployed-after: [ui5app2, unknown]
+
+ - name: ui5app2
+   type: html5
+   deployed-after:
+   - ui5app3
+
+ - name: ui5app3
+   type: html5
+   deployed-after:
+   - ui5app1
+   - ui5app3
+
+ - name: ui5app4
+   type: html5
+   deployed-after: [ui5app1]
+`)
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		errors, warn := checkDeployedAfter(mta, node, "", true)
+		Ω(len(warn)).Should(Equal(0))
+		Ω(errors).Should(Equal([]YamlValidationIssue{
+			{Msg: `the "ui5app1" module is deployed after the "unknown" module, which is not defined`, Line: 12},
+			{Msg: `the "ui5app3" module is deployed after itself`, Line: 23},
+			{Msg: `the "ui5app3" module is deployed after the "ui5app1" module in a cycle: ui5app1 -> ui5app2 -> ui5app3 -> ui5app1`, Line: 22},
+		}))
+	})
+
+	It("warns when parallel deployment is not enabled", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+ - name: ui5app1
+   type: html5
+   deployed-after: [ui5app2]
+
+ - name: ui5app2
+   type: html5
+`)
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		errors, warn := checkDeployedAfter(mta, node, "", true)
+		Ω(len(errors)).Should(Equal(0))
+		Ω(warn).Should(Equal([]YamlValidationIssue{
+			{Msg: fmt.Sprintf(deployedAfterWithoutParallelMsg, "ui5app1", enableParallelDeploymentsYamlField), Line: 9},
+		}))
+	})
+
+	It("does not report the modules with the same name, which are reported by the names validation", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+parameters:
+  enable-parallel-deployments: true
+
+modules:
+ - name: ui5app1
+   type: html5
+
+ - name: ui5app2
+   type: html5
+   deployed-after: [ui5app1]
+
+ - name: ui5app1
+   type: html5
+`)
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		errors, warn := checkDeployedAfter(mta, node, "", true)
+		Ω(errors).Should(BeEmpty())
+		Ω(warn).Should(BeEmpty())
+
+		errors, _ = runSemanticValidations(mta, node, "", "paths", true)
+		Ω(len(errors)).Should(Equal(1))
+		Ω(errors[0].Line).Should(Equal(17))
+	})
+
+	It("is excluded from the semantic validations", func() {
+		Ω(len(getSemanticValidations("", nil))).Should(Equal(len(getSemanticValidations(deployedAfterValidation, nil)) + 1))
+	})
+})
@@ -55,6 +55,7 @@ func checkHTML5ModuleParams(module *mta.Module, moduleNode *yaml.Node) []YamlVal
 			{
 				Msg:  fmt.Sprintf(missingConfigsMsg, module.Name, missingConfigDocLink),
 				Line: moduleNode.Line,
+				Fix:  getMissingConfigsFix(module.Name, moduleNode, supportedPlatformsDefined, buildResultDefined),
 			},
 		}
 	} else if !supportedPlatformsDefined {
@@ -62,6 +63,7 @@ func checkHTML5ModuleParams(module *mta.Module, moduleNode *yaml.Node) []YamlVal
 			{
 				Msg:  fmt.Sprintf(missingConfigMsg, module.Name, supportedPlatformsYamlField, missingConfigDocLink),
 				Line: moduleNode.Line,
+				Fix:  getMissingConfigsFix(module.Name, moduleNode, supportedPlatformsDefined, buildResultDefined),
 			},
 		}
 	} else if !buildResultDefined {
@@ -69,6 +71,7 @@ func checkHTML5ModuleParams(module *mta.Module, moduleNode *yaml.Node) []YamlVal
 			{
 				Msg:  fmt.Sprintf(missingConfigMsg, module.Name, buildResultYamlField, missingConfigDocLink),
 				Line: moduleNode.Line,
+				Fix:  getMissingConfigsFix(module.Name, moduleNode, supportedPlatformsDefined, buildResultDefined),
 			},
 		}
 	}
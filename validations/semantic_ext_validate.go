@@ -2,35 +2,58 @@ package validate
 
 import (
 	"gopkg.in/yaml.v3"
-	"strings"
 
 	"github.com/SAP/cloud-mta/mta"
 )
 
 type checkExtSemantic func(mta *mta.EXT, root *yaml.Node, source string, strict bool) (errors []YamlValidationIssue, warnings []YamlValidationIssue)
 
-// runSemanticValidations - runs semantic validations
+// extSemanticValidation - a semantic validation of MTA extensions with the name of its rule
+type extSemanticValidation struct {
+	name  string
+	check checkExtSemantic
+}
+
+// runExtSemanticValidations - runs semantic validations
 func runExtSemanticValidations(mtaExt *mta.EXT, root *yaml.Node, source string, exclude string, strict bool) ([]YamlValidationIssue, []YamlValidationIssue) {
+	return runRuleExtSemanticValidations(mtaExt, root, source, exclude, newRuleContext(nil, root, "", strict))
+}
+
+// runRuleExtSemanticValidations - runs the semantic validations which are enabled by the rule configuration,
+// and applies the configuration and the suppression comments to their issues
+func runRuleExtSemanticValidations(mtaExt *mta.EXT, root *yaml.Node, source string, exclude string, rules *ruleContext) ([]YamlValidationIssue, []YamlValidationIssue) {
 	var errors []YamlValidationIssue
 	var warnings []YamlValidationIssue
 
 	validations := getExtSemanticValidations(exclude)
+	for _, rule := range rules.getCustomRules() {
+		if !isExcluded(exclude, rule.ID) {
+			validations = append(validations, extSemanticValidation{rule.ID, rule.checkExt})
+		}
+	}
 	for _, validation := range validations {
-		validationErrors, validationWarnings := validation(mtaExt, root, source, strict)
+		if !rules.isEnabled(validation.name) {
+			continue
+		}
+		validationErrors, validationWarnings := validation.check(mtaExt, root, source, rules.isStrict(validation.name))
+		validationErrors, validationWarnings = rules.apply(validation.name, validationErrors, validationWarnings)
 		errors = append(errors, validationErrors...)
 		warnings = append(warnings, validationWarnings...)
 	}
 	return errors, warnings
 }
 
-// getSemanticValidations - gets list of all semantic validations minus excludes validations
-func getExtSemanticValidations(exclude string) []checkExtSemantic {
-	var validations []checkExtSemantic
-	if !strings.Contains(exclude, namesValidation) {
-		validations = append(validations, checkSingleExtendNames)
+// getExtSemanticValidations - gets list of all semantic validations of MTA extensions minus excludes validations
+func getExtSemanticValidations(exclude string) []extSemanticValidation {
+	allValidations := []extSemanticValidation{
+		{namesValidation, checkSingleExtendNames},
+		{deprecatedOptsValidation, checkExtDeprecatedOpts},
 	}
-	if !strings.Contains(exclude, deprecatedOptsValidation) {
-		validations = append(validations, checkExtDeprecatedOpts)
+	var validations []extSemanticValidation
+	for _, validation := range allValidations {
+		if !isExcluded(exclude, validation.name) {
+			validations = append(validations, validation)
+		}
 	}
 	return validations
 }
@@ -0,0 +1,157 @@
+package validate
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"sort"
+	"strings"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	hookNameNotUniqueMsg     = `the "%s" hook name is already in use in the "%s" module; another hook was found with the same name on line %d`
+	hookUnknownPhaseMsg      = `the "%s" phase of the "%s" hook of the "%s" module is not supported; use one of: %s`
+	hookUnknownTypeMsg       = `the "%s" type of the "%s" hook of the "%s" module is not supported; use one of: %s`
+	hookRequiresUndefinedMsg = `the "%s" property set required by the "%s" hook of the "%s" module is not defined`
+	hookMissingParameterMsg  = `the "%s" hook of the "%s" module is of the "%s" type, but the "%s" parameter is not defined`
+
+	phasesYamlField  = "phases"
+	typeYamlField    = "type"
+	commandParameter = "command"
+
+	taskHookType = "task"
+)
+
+// hookPhases are the phases of the deployment in which the hooks can be executed
+var hookPhases = map[string]bool{
+	"deploy.application.before-stop":                  true,
+	"deploy.application.after-stop":                   true,
+	"deploy.application.before-unmap-routes":          true,
+	"deploy.application.before-start":                 true,
+	"blue-green.application.before-stop.idle":         true,
+	"blue-green.application.before-stop.live":         true,
+	"blue-green.application.after-stop.idle":          true,
+	"blue-green.application.after-stop.live":          true,
+	"blue-green.application.before-unmap-routes.idle": true,
+	"blue-green.application.before-unmap-routes.live": true,
+	"blue-green.application.before-start.idle":        true,
+	"blue-green.application.before-start.live":        true,
+	// the phases without a deployment strategy prefix are supported for backward compatibility
+	"application.before-stop":         true,
+	"application.after-stop":          true,
+	"application.before-unmap-routes": true,
+	// the phases of the MTA specification
+	"application.before-stop.live": true,
+	"application.before-stop.idle": true,
+	"application.after-stop.live":  true,
+	"application.after-stop.idle":  true,
+}
+
+// hookTypes are the types of the hooks and the parameters they require
+var hookTypes = map[string][]string{
+	taskHookType: {commandParameter},
+}
+
+// checkHooks validates the hooks of the modules: their names are unique in the module, their phases and types are
+// supported, the parameters required by their type are defined and the property sets they require are defined.
+// The phases, the types and the parameters of the hooks are also checked by the schema from the 3.3 schema version,
+// so they are only checked here when the schema does not report them.
+func checkHooks(mta *mta.MTA, mtaNode *yaml.Node, source string, strict bool) ([]YamlValidationIssue, []YamlValidationIssue) {
+	var issues []YamlValidationIssue
+	checkedBySchema := isHookCheckedBySchema(mta)
+
+	provided, configurationProvided := getProvidedPropertySets(mta)
+
+	modulesNode := getPropContent(mtaNode, modulesYamlField)
+	for i, module := range mta.Modules {
+		hooksNode := getPropContent(modulesNode[i], hooksYamlField)
+		names := make(map[string]int)
+		for j, hook := range module.Hooks {
+			hookNode := hooksNode[j]
+			line, _ := getIndexedNodePropLine(getPropValueByName(modulesNode[i], hooksYamlField), j, nameYamlField)
+			if prevLine, ok := names[hook.Name]; ok {
+				issues = appendIssue(issues, fmt.Sprintf(hookNameNotUniqueMsg, hook.Name, module.Name, prevLine), line)
+			} else {
+				names[hook.Name] = line
+			}
+
+			phasesNode := getPropContent(hookNode, phasesYamlField)
+			for k, phase := range hook.Phases {
+				if !checkedBySchema && !hookPhases[phase] {
+					issues = appendIssue(issues,
+						fmt.Sprintf(hookUnknownPhaseMsg, phase, hook.Name, module.Name, getSortedKeys(hookPhases)), phasesNode[k].Line)
+				}
+			}
+
+			issues = append(issues, checkHookType(hook, module.Name, hookNode, line, checkedBySchema)...)
+
+			requiresNode := getPropValueByName(hookNode, requiresYamlField)
+			for k, requires := range hook.Requires {
+				_, contains := provided[requires.Name]
+				if !contains && !configurationProvided[requires.Name] {
+					line, _ := getIndexedNodePropLine(requiresNode, k, nameYamlField)
+					issues = appendIssue(issues, fmt.Sprintf(hookRequiresUndefinedMsg, requires.Name, hook.Name, module.Name), line)
+				}
+			}
+		}
+	}
+	return issues, nil
+}
+
+// checkHookType checks that the type of the hook is supported and that the parameters required by the type are defined
+func checkHookType(hook mta.Hook, moduleName string, hookNode *yaml.Node, hookLine int, checkedBySchema bool) []YamlValidationIssue {
+	typeNode := getPropValueByName(hookNode, typeYamlField)
+	if typeNode == nil {
+		return nil
+	}
+	requiredParams, ok := hookTypes[hook.Type]
+	if !ok {
+		if checkedBySchema {
+			return nil
+		}
+		types := make([]string, 0, len(hookTypes))
+		for hookType := range hookTypes {
+			types = append(types, hookType)
+		}
+		sort.Strings(types)
+		return appendIssue(nil,
+			fmt.Sprintf(hookUnknownTypeMsg, hook.Type, hook.Name, moduleName, strings.Join(types, ", ")), typeNode.Line)
+	}
+
+	var issues []YamlValidationIssue
+	paramsNode := getPropByName(hookNode, parametersYamlField)
+	// the schema only checks the parameters of the hooks which have parameters
+	if checkedBySchema && paramsNode != nil {
+		return nil
+	}
+	for _, param := range requiredParams {
+		if _, ok := hook.Parameters[param]; !ok {
+			line := hookLine
+			if paramsNode != nil {
+				line = paramsNode.Line
+			}
+			issues = appendIssue(issues, fmt.Sprintf(hookMissingParameterMsg, hook.Name, moduleName, hook.Type, param), line)
+		}
+	}
+	return issues
+}
+
+// isHookCheckedBySchema returns true if the schema of the MTA schema version checks the phases, the types
+// and the parameters of the hooks
+func isHookCheckedBySchema(mta *mta.MTA) bool {
+	if mta.SchemaVersion == nil {
+		return false
+	}
+	version, ok := getSchemaVersion(*mta.SchemaVersion)
+	return ok && !version.isBefore(3, 3)
+}
+
+func getSortedKeys(m map[string]bool) string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
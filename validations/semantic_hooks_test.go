@@ -0,0 +1,145 @@
+package validate
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("SemanticHooks", func() {
+	It("Sanity", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+ - name: srv
+   type: java
+   provides:
+   - name: srv_api
+   hooks:
+   - name: hook1
+     type: task
+     phases:
+     - blue-green.application.before-stop.live
+     - deploy.application.before-start
+     parameters:
+       command: npm run migrate
+     requires:
+     - name: srv_api
+     - name: db
+   - name: hook2
+     type: task
+     phases: [deploy.application.before-start, deploy.application.after-start]
+     parameters:
+       name: migrate
+     requires:
+     - name: unknown
+   - name: hook1
+     type: script
+   - name: hook3
+     type: task
+
+resources:
+ - name: db
+   type: com.sap.xs.hdi-container
+`)
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		errors, warnings := checkHooks(mta, node, "", true)
+		Ω(warnings).Should(BeNil())
+		Ω(errors).Should(HaveLen(6))
+		Ω(errors[0].Msg).Should(HavePrefix(`the "deploy.application.after-start" phase of the "hook2" hook of the "srv" module is not supported; use one of: application.after-stop, `))
+		Ω(errors[0].Line).Should(Equal(24))
+		Ω(errors[1:]).Should(Equal([]YamlValidationIssue{
+			{Msg: `the "hook2" hook of the "srv" module is of the "task" type, but the "command" parameter is not defined`, Line: 25},
+			{Msg: `the "unknown" property set required by the "hook2" hook of the "srv" module is not defined`, Line: 28},
+			{Msg: `the "hook1" hook name is already in use in the "srv" module; another hook was found with the same name on line 12`, Line: 29},
+			{Msg: `the "script" type of the "hook1" hook of the "srv" module is not supported; use one of: task`, Line: 30},
+			{Msg: `the "hook3" hook of the "srv" module is of the "task" type, but the "command" parameter is not defined`, Line: 31},
+		}))
+	})
+
+	It("does not report the issues reported by the schema of the 3.3 schema version", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.3'
+version: 0.0.1
+
+modules:
+ - name: srv
+   type: java
+   hooks:
+   - name: hook1
+     type: script
+     phases: [unknown]
+     parameters:
+       memory: 1X
+   - name: hook2
+     type: task
+`)
+		errors, _ := validateWithSchemaVersion(mtaContent, "", "", "", true, true, true, "paths")
+		errors.Sort()
+		var lines []int
+		for _, issue := range errors {
+			lines = append(lines, issue.Line)
+		}
+		// the type, the phase, the missing command and the memory of hook1 are reported by the schema, once
+		Ω(lines).Should(Equal([]int{11, 12, 14, 14, 15}))
+		Ω(errors[4].Msg).Should(Equal(fmt.Sprintf(hookMissingParameterMsg, "hook2", "srv", taskHookType, commandParameter)))
+	})
+
+	It("reports the hook requires entries without a name on their first line", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+ - name: srv
+   type: java
+   hooks:
+   - name: hook1
+     type: task
+     parameters:
+       command: npm run migrate
+     requires:
+     - properties:
+         a: b
+`)
+		mta, err := mta.Unmarshal(mtaContent)
+		Ω(err).Should(Succeed())
+		node, _ := getContentNode(mtaContent)
+		errors, warnings := checkHooks(mta, node, "", true)
+		Ω(warnings).Should(BeNil())
+		Ω(errors).Should(Equal([]YamlValidationIssue{
+			{Msg: fmt.Sprintf(hookRequiresUndefinedMsg, "", "hook1", "srv"), Line: 15},
+		}))
+
+		errors, _ = validateWithSchemaVersion(mtaContent, "", "", "", true, true, true, "paths")
+		Ω(errors).ShouldNot(BeEmpty())
+	})
+
+	It("is excluded by the exclude list", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+ - name: srv
+   type: java
+   hooks:
+   - name: hook1
+     type: script
+`)
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		errors, _ := runSemanticValidations(mta, node, "", "paths,hooks", true)
+		Ω(errors).Should(BeEmpty())
+	})
+})
@@ -130,22 +130,22 @@ resources:
 			errors, warn := checkParamsAndPropertiesMetadata(mta, node, "", true)
 			Ω(len(warn)).Should(Equal(0))
 			Ω(errors).Should(ConsistOf(
-				YamlValidationIssue{fmt.Sprintf(unknownNameInMetadataMsg, "b", "parameter"), 11},
-				YamlValidationIssue{fmt.Sprintf(emptyRequiredFieldMsg, "memory", "parameter"), 18},
-				YamlValidationIssue{fmt.Sprintf(unknownNameInMetadataMsg, "x", "property"), 27},
-				YamlValidationIssue{fmt.Sprintf(unknownNameInMetadataMsg, "b", "parameter"), 34},
-				YamlValidationIssue{fmt.Sprintf(unknownNameInMetadataMsg, "a", "parameter"), 41},
-				YamlValidationIssue{fmt.Sprintf(unknownNameInMetadataMsg, "b", "property"), 51},
-				YamlValidationIssue{fmt.Sprintf(unknownNameInMetadataMsg, "a", "parameter"), 56},
-				YamlValidationIssue{propertiesMetadataWithListOrGroupMsg, 50},
-				YamlValidationIssue{fmt.Sprintf(unknownNameInMetadataMsg, "b", "property"), 65},
-				YamlValidationIssue{fmt.Sprintf(unknownNameInMetadataMsg, "m", "parameter"), 74},
-				YamlValidationIssue{fmt.Sprintf(emptyRequiredFieldMsg, "b", "property"), 81},
-				YamlValidationIssue{fmt.Sprintf(unknownNameInMetadataMsg, "b", "property"), 94},
-				YamlValidationIssue{fmt.Sprintf(unknownNameInMetadataMsg, "a", "parameter"), 99},
-				YamlValidationIssue{propertiesMetadataWithListOrGroupMsg, 93},
-				YamlValidationIssue{propertiesMetadataWithListOrGroupMsg, 107},
-				YamlValidationIssue{propertiesMetadataWithListOrGroupMsg, 110},
+				YamlValidationIssue{Msg: fmt.Sprintf(unknownNameInMetadataMsg, "b", "parameter"), Line: 11},
+				YamlValidationIssue{Msg: fmt.Sprintf(emptyRequiredFieldMsg, "memory", "parameter"), Line: 18},
+				YamlValidationIssue{Msg: fmt.Sprintf(unknownNameInMetadataMsg, "x", "property"), Line: 27},
+				YamlValidationIssue{Msg: fmt.Sprintf(unknownNameInMetadataMsg, "b", "parameter"), Line: 34},
+				YamlValidationIssue{Msg: fmt.Sprintf(unknownNameInMetadataMsg, "a", "parameter"), Line: 41},
+				YamlValidationIssue{Msg: fmt.Sprintf(unknownNameInMetadataMsg, "b", "property"), Line: 51},
+				YamlValidationIssue{Msg: fmt.Sprintf(unknownNameInMetadataMsg, "a", "parameter"), Line: 56},
+				YamlValidationIssue{Msg: propertiesMetadataWithListOrGroupMsg, Line: 50},
+				YamlValidationIssue{Msg: fmt.Sprintf(unknownNameInMetadataMsg, "b", "property"), Line: 65},
+				YamlValidationIssue{Msg: fmt.Sprintf(unknownNameInMetadataMsg, "m", "parameter"), Line: 74},
+				YamlValidationIssue{Msg: fmt.Sprintf(emptyRequiredFieldMsg, "b", "property"), Line: 81},
+				YamlValidationIssue{Msg: fmt.Sprintf(unknownNameInMetadataMsg, "b", "property"), Line: 94},
+				YamlValidationIssue{Msg: fmt.Sprintf(unknownNameInMetadataMsg, "a", "parameter"), Line: 99},
+				YamlValidationIssue{Msg: propertiesMetadataWithListOrGroupMsg, Line: 93},
+				YamlValidationIssue{Msg: propertiesMetadataWithListOrGroupMsg, Line: 107},
+				YamlValidationIssue{Msg: propertiesMetadataWithListOrGroupMsg, Line: 110},
 			))
 		})
 
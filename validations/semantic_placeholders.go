@@ -0,0 +1,117 @@
+package validate
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+
+	"github.com/SAP/cloud-mta/mta"
+	"github.com/SAP/cloud-mta/resolver"
+)
+
+const (
+	unknownPlaceholderMsg = `the "%s" %s of the %s contains the "${%s}" placeholder, which does not match any parameter`
+)
+
+// placeholdersChecker checks the ${...} placeholders in the values of an MTA entity
+type placeholdersChecker struct {
+	// providerParameters are the parameters of the providers of the property sets which can be required:
+	// the parameters of the module which provides the property set, or of the resource
+	providerParameters map[string]map[string]interface{}
+	warnings           []YamlValidationIssue
+}
+
+// checkPlaceholders warns on ${...} placeholders in properties and parameters which do not match any parameter.
+// The parameters are looked up like in the resolver: in the parameters of the required property set's provider
+// and of the requires entry for values in a requires entry, then in the parameters of the module or resource,
+// then in the MTA parameters. The parameters set by the deployer, like "default-url", are always known.
+func checkPlaceholders(mta *mta.MTA, mtaNode *yaml.Node, source string, strict bool) ([]YamlValidationIssue, []YamlValidationIssue) {
+	c := placeholdersChecker{providerParameters: make(map[string]map[string]interface{})}
+	// the resolver looks for the provided property sets before the resources
+	for _, resource := range mta.Resources {
+		c.providerParameters[resource.Name] = resource.Parameters
+	}
+	for _, module := range mta.Modules {
+		for _, provides := range module.Provides {
+			c.providerParameters[provides.Name] = module.Parameters
+		}
+	}
+
+	c.checkFields(mtaNode, []string{parametersYamlField}, "MTA", mta.Parameters)
+
+	modulesNode := getPropContent(mtaNode, modulesYamlField)
+	for i, module := range mta.Modules {
+		moduleDesc := fmt.Sprintf(`"%s" module`, module.Name)
+		c.checkFields(modulesNode[i], []string{propertiesYamlField, parametersYamlField}, moduleDesc,
+			module.Parameters, mta.Parameters)
+		c.checkRequires(modulesNode[i], module.Requires, moduleDesc, module.Parameters, mta.Parameters)
+
+		providesNode := getPropContent(modulesNode[i], providesYamlField)
+		for j, provides := range module.Provides {
+			c.checkFields(providesNode[j], []string{propertiesYamlField},
+				fmt.Sprintf(`"%s" provided property set of the %s`, provides.Name, moduleDesc), module.Parameters, mta.Parameters)
+		}
+
+		hooksNode := getPropContent(modulesNode[i], hooksYamlField)
+		for j, hook := range module.Hooks {
+			// the parameters of the hook are resolved in the scope of the module
+			hookDesc := fmt.Sprintf(`"%s" hook of the %s`, hook.Name, moduleDesc)
+			c.checkFields(hooksNode[j], []string{parametersYamlField}, hookDesc, module.Parameters, mta.Parameters)
+			c.checkRequires(hooksNode[j], hook.Requires, hookDesc, module.Parameters, mta.Parameters)
+		}
+	}
+
+	resourcesNode := getPropContent(mtaNode, resourcesYamlField)
+	for i, resource := range mta.Resources {
+		resourceDesc := fmt.Sprintf(`"%s" resource`, resource.Name)
+		c.checkFields(resourcesNode[i], []string{propertiesYamlField, parametersYamlField}, resourceDesc,
+			resource.Parameters, mta.Parameters)
+		c.checkRequires(resourcesNode[i], resource.Requires, resourceDesc, mta.Parameters)
+	}
+	return nil, c.warnings
+}
+
+// checkRequires checks the placeholders in the properties and parameters of the requires entries of the entity.
+// The parameters of the provider and of the requires entry are looked up before the parameters in the scopes.
+func (c *placeholdersChecker) checkRequires(node *yaml.Node, requires []mta.Requires, entityDesc string, scopes ...map[string]interface{}) {
+	requiresNode := getPropContent(node, requiresYamlField)
+	for i, req := range requires {
+		requiresScopes := append([]map[string]interface{}{c.providerParameters[req.Name], req.Parameters}, scopes...)
+		c.checkFields(requiresNode[i], []string{propertiesYamlField, parametersYamlField}, entityDesc, requiresScopes...)
+	}
+}
+
+// checkFields checks the placeholders in the values of the fields of the node against the parameters in the scopes
+func (c *placeholdersChecker) checkFields(node *yaml.Node, fields []string, entityDesc string, scopes ...map[string]interface{}) {
+	for _, field := range fields {
+		kind := propertyEntityKind
+		if field == parametersYamlField {
+			kind = parameterEntityKind
+		}
+		valuesNode := getPropValueByName(node, field)
+		if valuesNode == nil || valuesNode.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(valuesNode.Content); i += 2 {
+			forEachStringValue(valuesNode.Content[i+1], valuesNode.Content[i].Value, func(name string, valueNode *yaml.Node) {
+				for _, placeholder := range resolver.GetPlaceholders(valueNode.Value) {
+					if !isParameterDefined(placeholder, scopes) {
+						c.warnings = appendIssue(c.warnings,
+							fmt.Sprintf(unknownPlaceholderMsg, name, kind, entityDesc, placeholder), valueNode.Line)
+					}
+				}
+			})
+		}
+	}
+}
+
+func isParameterDefined(name string, scopes []map[string]interface{}) bool {
+	if resolver.IsPlatformParameter(name) {
+		return true
+	}
+	for _, scope := range scopes {
+		if _, ok := scope[name]; ok {
+			return true
+		}
+	}
+	return false
+}
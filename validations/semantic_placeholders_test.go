@@ -0,0 +1,92 @@
+package validate
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("SemanticPlaceholders", func() {
+	It("Sanity", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+parameters:
+  env: dev
+  domain-suffix: ${env}.${unknown-root}
+
+modules:
+ - name: srv
+   type: java
+   parameters:
+     memory: 256M
+     route: ${host}.${domain-suffix}
+   properties:
+     url: ${defualt-url}
+     mem: ${memory}
+   provides:
+   - name: srv_api
+     properties:
+       url: ${default-url}/${memory}/${missing}
+   requires:
+   - name: db
+     parameters:
+       schema: ${db-schema}
+       plan: [ "${service-plan}", "${host}" ]
+     properties:
+       schema: ${schema}
+   hooks:
+   - name: hook1
+     parameters:
+       name: ${memory}-${hook-param}
+       hook-param: x
+
+resources:
+ - name: db
+   type: com.sap.xs.hdi-container
+   parameters:
+     service-plan: hdi-shared
+     db-schema: ${env}
+   properties:
+     schema: ${db-schema}/${memory}
+   requires:
+   - name: srv_api
+     properties:
+       url: ${memory}
+`)
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		errors, warnings := checkPlaceholders(mta, node, "", true)
+		Ω(errors).Should(BeNil())
+		Ω(warnings).Should(Equal([]YamlValidationIssue{
+			{Msg: `the "domain-suffix" parameter of the MTA contains the "${unknown-root}" placeholder, which does not match any parameter`, Line: 8},
+			{Msg: `the "url" property of the "srv" module contains the "${defualt-url}" placeholder, which does not match any parameter`, Line: 17},
+			{Msg: `the "url" property of the "srv_api" provided property set of the "srv" module contains the "${missing}" placeholder, which does not match any parameter`, Line: 22},
+			{Msg: `the "name" parameter of the "hook1" hook of the "srv" module contains the "${hook-param}" placeholder, which does not match any parameter`, Line: 33},
+			{Msg: `the "schema" property of the "db" resource contains the "${memory}" placeholder, which does not match any parameter`, Line: 43},
+		}))
+	})
+
+	It("is excluded by the exclude list", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+ - name: srv
+   type: java
+   properties:
+     url: ${defualt-url}
+`)
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		_, warnings := runSemanticValidations(mta, node, "", "paths,placeholders", true)
+		Ω(warnings).Should(BeEmpty())
+		_, warnings = runSemanticValidations(mta, node, "", "paths", true)
+		Ω(warnings).Should(HaveLen(1))
+	})
+})
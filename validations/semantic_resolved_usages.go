@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"gopkg.in/yaml.v3"
 	"reflect"
-	"regexp"
-	"strings"
 
 	"github.com/SAP/cloud-mta/mta"
 )
@@ -14,6 +12,24 @@ import (
 func ifRequiredDefined(mta *mta.MTA, mtaNode *yaml.Node, source string, strict bool) ([]YamlValidationIssue, []YamlValidationIssue) {
 	var issues []YamlValidationIssue
 
+	provided, configurationProvided := getProvidedPropertySets(mta)
+
+	modulesNode := getPropContent(mtaNode, modulesYamlField)
+	for i, module := range mta.Modules {
+		issues = append(issues, checkComponent(provided, configurationProvided, module, modulesNode[i], "module")...)
+	}
+
+	resourcesNode := getPropContent(mtaNode, resourcesYamlField)
+	for i, resource := range mta.Resources {
+		issues = append(issues, checkComponent(provided, configurationProvided, resource, resourcesNode[i], "resource")...)
+	}
+	return issues, nil
+}
+
+// getProvidedPropertySets returns the properties of the property sets which can be required: the modules,
+// the property sets provided by the modules and the resources, and the names of the resources of configuration type,
+// which provide properties that are not known in the MTA
+func getProvidedPropertySets(mta *mta.MTA) (map[string]map[string]interface{}, map[string]bool) {
 	// init set of all provided property sets
 	provided := make(map[string]map[string]interface{})
 
@@ -37,32 +53,7 @@ func ifRequiredDefined(mta *mta.MTA, mtaNode *yaml.Node, source string, strict b
 			provided[resource.Name] = resource.Properties
 		}
 	}
-
-	modulesNode := getPropContent(mtaNode, modulesYamlField)
-	for i, module := range mta.Modules {
-		issues = append(issues, checkComponent(provided, configurationProvided, module, modulesNode[i], "module")...)
-		for j, moduleProvides := range module.Provides {
-			providesNode := getPropValueByName(modulesNode[i], providesYamlField)
-			issues = append(issues, checkComponent(provided, configurationProvided, &moduleProvides, providesNode.Content[j], "provided property set of the "+module.Name+" module")...)
-		}
-	}
-
-	resourcesNode := getPropContent(mtaNode, resourcesYamlField)
-	for i, resource := range mta.Resources {
-		issues = append(issues, checkComponent(provided, configurationProvided, resource, resourcesNode[i], "resource")...)
-	}
-	return issues, nil
-}
-
-func structFieldToMap(str interface{}, field string) map[string]interface{} {
-	v := reflect.ValueOf(str).Elem().FieldByName(field)
-	if v.IsValid() {
-		mapValue, ok := v.Addr().Interface().(*map[string]interface{})
-		if ok {
-			return *mapValue
-		}
-	}
-	return nil
+	return provided, configurationProvided
 }
 
 func structFieldToRequires(str interface{}) []mta.Requires {
@@ -86,19 +77,7 @@ func checkComponent(provided map[string]map[string]interface{}, configurationPro
 	var issues []YamlValidationIssue
 
 	compName := structFieldToString(component)
-	propsNode := getPropValueByName(compNode, propertiesYamlField)
-	issues = append(issues,
-		checkRequiredProperties(provided, configurationProvided, "", structFieldToMap(component, propertiesMtaField),
-			fmt.Sprintf(`"%s" %s`, compName, compDesc), propsNode, propertyEntityKind)...)
-	paramsNode := getPropValueByName(compNode, parametersYamlField)
-	issues = append(issues,
-		checkRequiredProperties(provided, configurationProvided, "", structFieldToMap(component, parametersMtaField),
-			fmt.Sprintf(`"%s" %s`, compName, compDesc), paramsNode, parameterEntityKind)...)
-	buildParamsNode := getPropValueByName(compNode, buildParametersYamlField)
-	issues = append(issues,
-		checkRequiredProperties(provided, configurationProvided, "", structFieldToMap(component, buildParametersMtaField),
-			fmt.Sprintf(`"%s" %s`, compName, compDesc), buildParamsNode, buildParamEntityKind)...)
-	// check that each required by resource property set was provided in mta.yaml
+	// check that each required property set was provided in mta.yaml
 	requiresNode := getPropValueByName(compNode, requiresYamlField)
 	for i, requires := range structFieldToRequires(component) {
 		_, contains := provided[requires.Name]
@@ -109,100 +88,6 @@ func checkComponent(provided map[string]map[string]interface{}, configurationPro
 				fmt.Sprintf(`the "%s" property set required by the "%s" %s is not defined`,
 					requires.Name, compName, compDesc), line)
 		}
-		// check that each property of resource is resolved
-		reqPropsNode := getPropValueByName(requiresNode.Content[i], propertiesYamlField)
-		issues = append(issues,
-			checkRequiredProperties(provided, configurationProvided, requires.Name, requires.Properties,
-				fmt.Sprintf(`"%s" %s`, compName, compDesc), reqPropsNode, propertyEntityKind)...)
-		// check that each parameter of resource is resolved
-		reqParamsNode := getPropValueByName(requiresNode.Content[i], parametersYamlField)
-		issues = append(issues,
-			checkRequiredProperties(provided, configurationProvided, requires.Name, requires.Parameters,
-				fmt.Sprintf(`"%s" %s`, compName, compDesc), reqParamsNode, parameterEntityKind)...)
-	}
-	return issues
-}
-
-func checkRequiredProperties(providedProps map[string]map[string]interface{}, configurationProvided map[string]bool, requiredPropSet string,
-	requiredEntities map[string]interface{}, requiringObject string, node *yaml.Node, entityKind string) []YamlValidationIssue {
-
-	var issues []YamlValidationIssue
-	if requiredEntities == nil {
-		return nil
-	}
-	for entityName, entityValue := range requiredEntities {
-		entityNode := getPropValueByName(node, entityName)
-		issues = append(issues, checkValue(providedProps, configurationProvided, entityName, entityKind, requiredPropSet, requiringObject, entityValue, entityNode)...)
-	}
-	return issues
-}
-
-func checkValue(providedProps map[string]map[string]interface{}, configurationProvided map[string]bool,
-	entityName, entityKind, propSet, requiringObject string, entityValue interface{}, node *yaml.Node) []YamlValidationIssue {
-	var issues []YamlValidationIssue
-	propValueStr, ok := entityValue.(string)
-	if ok {
-		// property is simple - check if it can be resolved
-		issues = checkStringEntityValue(providedProps, configurationProvided, entityName, propValueStr, entityKind, propSet, requiringObject, node)
-	} else {
-		propValueMap, ok := entityValue.(map[string]interface{})
-		if ok {
-			// property is a map
-			for key, value := range propValueMap {
-				childNode := getPropValueByName(node, key)
-				// check every sub property
-				issues = append(issues, checkValue(providedProps, configurationProvided, entityName+"."+key, entityKind, propSet, requiringObject, value, childNode)...)
-			}
-		}
 	}
 	return issues
 }
-
-func checkStringEntityValue(providedProps map[string]map[string]interface{}, configurationProvided map[string]bool,
-	entityName, entityValue, entityKind, propSet, requiringObject string, entityNode *yaml.Node) []YamlValidationIssue {
-	var issues []YamlValidationIssue
-	r := regexp.MustCompile(`~{[^{}]+}`)
-	// find all placeholders
-	matches := r.FindAllString(entityValue, -1)
-	for _, match := range matches {
-		// get placeholder pure name, removing tilda and brackets
-		requiredProp := strings.TrimPrefix(strings.TrimSuffix(match, "}"), "~{")
-		// if property set was not provided it has to be presented in placeholder
-		if propSet == "" {
-			// split placeholder to property set and property name
-			requiredPropArr := strings.SplitN(requiredProp, "/", 2)
-			if len(requiredPropArr) != 2 {
-				// no property set provided
-				issues = appendIssue(issues,
-					fmt.Sprintf(`the "%s" %s of the %s is unresolved; the "%s" property is not provided`,
-						entityName, entityKind, requiringObject, requiredProp), entityNode.Line)
-			} else {
-				// check existence of property if property set
-				issues = appendIssue(issues,
-					checkRequiredProperty(providedProps, configurationProvided, entityName, entityKind, requiredPropArr[0], requiredPropArr[1], requiringObject), entityNode.Line)
-			}
-		} else {
-			// check existence of property if property set
-			issues = appendIssue(issues,
-				checkRequiredProperty(providedProps, configurationProvided, entityName, entityKind, propSet, requiredProp, requiringObject), entityNode.Line)
-		}
-
-	}
-	return issues
-}
-
-func checkRequiredProperty(providedProps map[string]map[string]interface{}, configurationProvided map[string]bool, entityName, entityKind,
-	requiredSet, requiredProp, requiringObject string) string {
-	providedSet, ok := providedProps[requiredSet]
-	if ok {
-		_, ok = providedSet[requiredProp]
-	}
-	if !ok {
-		_, ok = configurationProvided[requiredSet]
-	}
-	if !ok {
-		return fmt.Sprintf(`the "%s" %s of the %s is unresolved; the "%s/%s" property is not provided`,
-			entityName, entityKind, requiringObject, requiredSet, requiredProp)
-	}
-	return ""
-}
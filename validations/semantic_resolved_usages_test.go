@@ -95,60 +95,4 @@ modules:
 		Ω(issues[0].Msg).Should(Equal(`the "test1" property set required by the "staticapp" module is not defined`))
 		Ω(issues[0].Line).Should(Equal(31))
 	})
-
-	It("check required properties (placeholders usage)", func() {
-		mtaContent := []byte(`
-ID: mtahtml5
-_schema-version: '2.1'
-version: 0.0.1
-
-modules:
- - name: pricing-ui
-   type: javascript.nodejs
-   requires:
-   - name: price_opt 
-     properties:
-       conn_string: "~{protocol}://~{uri}/odata/" 
-       conn_string1: "~{protocol1}://~{uri}/odata/"
-       x: 
-         xa: "~{protocol}://~{uri}/odata/"
-   - name: unknown
-     properties:
-       conn_string2: "~{protocol}://~{uri}/odata/"
-
-   properties: 
-     conn_string3: "~{protocol}://~{uri}/odata/" 
-     a: "~{price_opt/protocol}://~{price_opt/uri}/odata/"
-     b: "~{price_opt/protocol1}://~{price_opt/uri}/odata/"
-     c: "~{price_opt1/protocol}://~{price_opt/uri}/odata/"
-     complex: 
-       a: "~{price_opt1/protocol}://~{price_opt/uri}/odata/"
-       b: "~{price_opt/address}://~{price_opt/address1}/odata/"
-   parameters: 
-     aaa: "~{protocol}://~{uri}/odata/" 
-
- - name: pricing-backend
-   type: html5
-   provides:
-   - name: price_opt
-     properties:
-       protocol: http
-       uri: myhost.mydomain
-       uri1: ~{aaaa}
-       uri2: ~{pricing-ui/a}
-       address: 
-         protocolX: http
-         uriX: myhost.mydomain
-
- - name1: unnamed
-   type: html5
-   properties: 
-     conn_string: "~{price_opt/protocol}://~{price_opt/uri}/odata/" 
-`)
-		mta, err := mta.Unmarshal(mtaContent)
-		Ω(err).Should(HaveOccurred())
-		node, _ := getContentNode(mtaContent)
-		issues, _ := ifRequiredDefined(mta, node, "", true)
-		Ω(len(issues)).Should(Equal(13))
-	})
 })
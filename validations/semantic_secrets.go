@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	sensitiveLiteralValueMsg = `the "%s" %s is sensitive but has a literal value; provide it in an MTA extension or in the environment`
+	secretLiteralValueMsg    = `the "%s" %s looks like a secret but has a literal value; provide it in an MTA extension or in the environment`
+
+	placeholderPrefix = "${"
+	variablePrefix    = "~{"
+)
+
+// The names which look like secrets, after they are lowercased and their separators are removed
+var secretNames = []string{"password", "clientsecret", "token"}
+
+// checkPlaintextSecrets warns on properties and parameters which are marked as sensitive or which look like secrets,
+// and whose values are written in the MTA file instead of coming from an extension or the environment
+func checkPlaintextSecrets(mta *mta.MTA, mtaNode *yaml.Node, source string, strict bool) (errors []YamlValidationIssue, warnings []YamlValidationIssue) {
+	return nil, validateMetadata(mta, mtaNode, source, checkMetadataSecrets)
+}
+
+func checkMetadataSecrets(m map[string]interface{}, metadata map[string]mta.MetaData, parentNode *yaml.Node, mapType int) []YamlValidationIssue {
+	var issues []YamlValidationIssue
+	mapNode := getPropValueByName(parentNode, mapTypes[mapType].mapNodeName)
+	entityKind := mapTypes[mapType].entityKind
+	for i := 0; mapNode != nil && i+1 < len(mapNode.Content); i += 2 {
+		key, valueNode := mapNode.Content[i].Value, mapNode.Content[i+1]
+		if metadata[key].Sensitive {
+			if isLiteralValue(valueNode) {
+				issues = append(issues, YamlValidationIssue{Msg: fmt.Sprintf(sensitiveLiteralValueMsg, key, entityKind), Line: valueNode.Line})
+			}
+			continue
+		}
+		issues = append(issues, checkSecretValues(key, valueNode, entityKind)...)
+	}
+	return issues
+}
+
+// checkSecretValues checks the value of the key and the values nested in it, for keys which look like secrets
+func checkSecretValues(key string, valueNode *yaml.Node, entityKind string) []YamlValidationIssue {
+	if isSecretName(key) && isLiteralValue(valueNode) {
+		return []YamlValidationIssue{{Msg: fmt.Sprintf(secretLiteralValueMsg, key, entityKind), Line: valueNode.Line}}
+	}
+	var issues []YamlValidationIssue
+	switch valueNode.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(valueNode.Content); i += 2 {
+			issues = append(issues, checkSecretValues(key+"."+valueNode.Content[i].Value, valueNode.Content[i+1], entityKind)...)
+		}
+	case yaml.SequenceNode:
+		for i, item := range valueNode.Content {
+			issues = append(issues, checkSecretValues(fmt.Sprintf("%s[%d]", key, i), item, entityKind)...)
+		}
+	}
+	return issues
+}
+
+// isSecretName returns true if the last part of the key looks like a secret
+func isSecretName(key string) bool {
+	if pos := strings.LastIndexAny(key, ".]"); pos >= 0 {
+		key = key[pos+1:]
+	}
+	name := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	for _, secretName := range secretNames {
+		if strings.Contains(name, secretName) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLiteralValue returns true if the value is a non-empty scalar which does not get its value from
+// placeholders or variables
+func isLiteralValue(node *yaml.Node) bool {
+	if node.Kind != yaml.ScalarNode || node.Tag == "!!null" || len(node.Value) == 0 {
+		return false
+	}
+	return !strings.Contains(node.Value, placeholderPrefix) && !strings.Contains(node.Value, variablePrefix)
+}
@@ -0,0 +1,69 @@
+package validate
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("SemanticSecrets", func() {
+	mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+parameters:
+  deploy-token: abc
+modules:
+ - name: srv
+   type: java
+   properties:
+     DB_PASSWORD: secret
+     api-key: key
+     user-password: ${password}
+     empty_token:
+   properties-metadata:
+     api-key: {sensitive: true}
+   parameters:
+     config:
+       oauth:
+         clientSecret: secret
+         clientid: srv
+   requires:
+   - name: db
+     properties:
+       token: ~{token}
+
+resources:
+ - name: db
+   type: com.sap.xs.hdi-container
+   parameters:
+     service-key: key
+   parameters-metadata:
+     service-key: {sensitive: true}
+`)
+
+	It("warns on literal values of sensitive and secret-looking properties and parameters", func() {
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		errors, warnings := checkPlaintextSecrets(mta, node, "", true)
+		Ω(errors).Should(BeNil())
+		Ω(warnings).Should(ConsistOf(
+			YamlValidationIssue{Msg: `the "deploy-token" parameter looks like a secret but has a literal value; provide it in an MTA extension or in the environment`, Line: 7},
+			YamlValidationIssue{Msg: `the "DB_PASSWORD" property looks like a secret but has a literal value; provide it in an MTA extension or in the environment`, Line: 12},
+			YamlValidationIssue{Msg: `the "api-key" property is sensitive but has a literal value; provide it in an MTA extension or in the environment`, Line: 13},
+			YamlValidationIssue{Msg: `the "config.oauth.clientSecret" parameter looks like a secret but has a literal value; provide it in an MTA extension or in the environment`, Line: 21},
+			YamlValidationIssue{Msg: `the "service-key" parameter is sensitive but has a literal value; provide it in an MTA extension or in the environment`, Line: 32},
+		))
+	})
+
+	It("is excluded by the exclude list", func() {
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		_, warnings := runSemanticValidations(mta, node, "", "paths,plaintextSecrets", true)
+		for _, warning := range warnings {
+			Ω(warning.Msg).ShouldNot(ContainSubstring("literal value"))
+		}
+	})
+})
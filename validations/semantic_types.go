@@ -0,0 +1,106 @@
+package validate
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	unknownTypeMsg          = `the "%s" type of the "%s" %s is not known`
+	missingTypeParameterMsg = `the "%s" %s of the "%s" type does not define the required "%s" parameter`
+	unknownTypeParameterMsg = `the "%s" parameter of the "%s" %s is not known for the "%s" type`
+)
+
+// declaredType is a module type or a resource type declared in the MTA
+type declaredType struct {
+	extends    string
+	parameters map[string]interface{}
+}
+
+// checkTypes checks the types of the modules and resources against the type registry: it warns on unknown types
+// and on parameters which are not known for the type, and reports the required parameters of the type which are
+// not defined. A type declared in the "module-types" or "resource-types" of the MTA is known; it is checked
+// as the type it extends, with the parameters it declares.
+func (r *TypeRegistry) checkTypes(mta *mta.MTA, mtaNode *yaml.Node, source string, strict bool) ([]YamlValidationIssue, []YamlValidationIssue) {
+	var errors []YamlValidationIssue
+	var warnings []YamlValidationIssue
+
+	declaredModuleTypes := make(map[string]declaredType)
+	for _, moduleType := range mta.ModuleTypes {
+		declaredModuleTypes[moduleType.Name] = declaredType{moduleType.Extends, moduleType.Parameters}
+	}
+	modulesNode := getPropContent(mtaNode, modulesYamlField)
+	for i, module := range mta.Modules {
+		errs, warns := checkEntityType(moduleEntityKind, module.Name, module.Type, module.Parameters, modulesNode[i],
+			declaredModuleTypes, r.GetModuleType)
+		errors = append(errors, errs...)
+		warnings = append(warnings, warns...)
+	}
+
+	declaredResourceTypes := make(map[string]declaredType)
+	for _, resourceType := range mta.ResourceTypes {
+		declaredResourceTypes[resourceType.Name] = declaredType{resourceType.Extends, resourceType.Parameters}
+	}
+	resourcesNode := getPropContent(mtaNode, resourcesYamlField)
+	for i, resource := range mta.Resources {
+		errs, warns := checkEntityType(resourceEntityKind, resource.Name, resource.Type, resource.Parameters, resourcesNode[i],
+			declaredResourceTypes, r.GetResourceType)
+		errors = append(errors, errs...)
+		warnings = append(warnings, warns...)
+	}
+	return errors, warnings
+}
+
+// checkEntityType checks the type of a module or a resource
+func checkEntityType(entityKind, entityName, typeName string, parameters map[string]interface{}, node *yaml.Node,
+	declaredTypes map[string]declaredType, getType func(name string) (TypeDefinition, bool)) ([]YamlValidationIssue, []YamlValidationIssue) {
+	typeNode := getPropValueByName(node, typeYamlField)
+	if len(typeName) == 0 || typeNode == nil {
+		// a resource can be untyped; a module without a type is reported by the schema validations
+		return nil, nil
+	}
+
+	// follow the declared types to the registered type they extend, collecting the parameters they declare
+	inherited := make(map[string]bool)
+	visited := make(map[string]bool)
+	for declared, ok := declaredTypes[typeName]; ok && !visited[typeName]; declared, ok = declaredTypes[typeName] {
+		visited[typeName] = true
+		for param := range declared.parameters {
+			inherited[param] = true
+		}
+		if len(declared.extends) == 0 {
+			// the type is declared without a base type, so its parameters are not known
+			return nil, nil
+		}
+		typeName = declared.extends
+	}
+
+	def, ok := getType(typeName)
+	if !ok {
+		return nil, appendIssue(nil, fmt.Sprintf(unknownTypeMsg, typeName, entityName, entityKind), typeNode.Line)
+	}
+
+	var errors []YamlValidationIssue
+	var warnings []YamlValidationIssue
+	nameLine := node.Line
+	if nameNode := getPropValueByName(node, nameYamlField); nameNode != nil {
+		nameLine = nameNode.Line
+	}
+	for _, param := range def.RequiredParameters {
+		if _, ok := parameters[param]; !ok && !inherited[param] {
+			errors = appendIssue(errors, fmt.Sprintf(missingTypeParameterMsg, entityName, entityKind, typeName, param), nameLine)
+		}
+	}
+	paramsNode := getPropValueByName(node, parametersYamlField)
+	if paramsNode != nil && paramsNode.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(paramsNode.Content); i += 2 {
+			param := paramsNode.Content[i]
+			if !def.isParameterKnown(param.Value) {
+				warnings = appendIssue(warnings, fmt.Sprintf(unknownTypeParameterMsg, param.Value, entityName, entityKind, typeName), param.Line)
+			}
+		}
+	}
+	return errors, warnings
+}
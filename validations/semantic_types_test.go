@@ -0,0 +1,131 @@
+package validate
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("SemanticTypes", func() {
+	mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+ - name: srv
+   type: nodejs
+   parameters:
+     memory: 256M
+     memroy: 256M
+
+ - name: worker
+   type: com.acme.worker
+
+ - name: ui
+   type: nodjs
+
+ - name: backend
+   type: my.java
+   parameters:
+     memory: 1G
+
+resources:
+ - name: db
+   type: org.cloudfoundry.managed-service
+   parameters:
+     service: hana
+
+ - name: uaa
+   type: my.uaa
+
+ - name: untyped
+
+module-types:
+ - name: my.java
+   extends: my.base
+ - name: my.base
+   extends: java
+   parameters:
+     instances: 1
+
+resource-types:
+ - name: my.uaa
+   extends: org.cloudfoundry.managed-service
+   parameters:
+     service: xsuaa
+`)
+
+	It("Sanity", func() {
+		r := NewTypeRegistry()
+		Ω(r.LoadFile(getTestPath("types", "types.yaml"))).Should(Succeed())
+		r.AddResourceType(TypeDefinition{Name: "org.cloudfoundry.managed-service", RequiredParameters: []string{"service", "service-plan"}})
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		errors, warnings := r.checkTypes(mta, node, "", true)
+		Ω(errors).Should(Equal([]YamlValidationIssue{
+			{Msg: `the "worker" module of the "com.acme.worker" type does not define the required "queue" parameter`, Line: 13},
+			{Msg: `the "db" resource of the "org.cloudfoundry.managed-service" type does not define the required "service-plan" parameter`, Line: 25},
+			{Msg: `the "uaa" resource of the "org.cloudfoundry.managed-service" type does not define the required "service-plan" parameter`, Line: 30},
+		}))
+		Ω(warnings).Should(Equal([]YamlValidationIssue{
+			{Msg: `the "memroy" parameter of the "srv" module is not known for the "nodejs" type`, Line: 11},
+			{Msg: `the "nodjs" type of the "ui" module is not known`, Line: 17},
+		}))
+	})
+
+	It("does not loop on cyclic declared types", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+ - name: srv
+   type: type1
+
+module-types:
+ - name: type1
+   extends: type2
+ - name: type2
+   extends: type1
+`)
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		_, warnings := NewTypeRegistry().checkTypes(mta, node, "", true)
+		Ω(warnings).Should(Equal([]YamlValidationIssue{{Msg: `the "type1" type of the "srv" module is not known`, Line: 8}}))
+	})
+
+	It("reports the missing parameters of the modules and resources without a name on their first line", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+ - type: com.acme.worker
+
+resources:
+ - type: org.cloudfoundry.managed-service
+`)
+		r := NewTypeRegistry()
+		Ω(r.LoadFile(getTestPath("types", "types.yaml"))).Should(Succeed())
+		mta, err := mta.Unmarshal(mtaContent)
+		Ω(err).Should(Succeed())
+		node, _ := getContentNode(mtaContent)
+		errors, _ := r.checkTypes(mta, node, "", true)
+		Ω(errors).Should(Equal([]YamlValidationIssue{
+			{Msg: `the "" module of the "com.acme.worker" type does not define the required "queue" parameter`, Line: 7},
+			{Msg: `the "" resource of the "org.cloudfoundry.managed-service" type does not define the required "service" parameter`, Line: 10},
+		}))
+	})
+
+	It("is excluded by the exclude list", func() {
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		errors, warnings := runSemanticValidations(mta, node, "", "paths,registeredTypes,unusedResources", true)
+		Ω(errors).Should(BeEmpty())
+		Ω(warnings).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,76 @@
+package validate
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	unusedResourceMsg         = `the "%s" resource is not required by any module, hook or resource`
+	unusedOptionalResourceMsg = `the "%s" resource is optional and is not required by any module, hook or resource`
+	unusedInactiveResourceMsg = `the "%s" resource is not active and is not required by any module, hook or resource`
+	unusedProvidesMsg         = `the "%s" property set provided by the "%s" module is not required by any module, hook or resource and is not public`
+)
+
+// checkUnusedResources warns on resources which are not listed in the requires of any module, hook or resource.
+// Optional and inactive resources are reported with a separate message.
+func checkUnusedResources(mta *mta.MTA, mtaNode *yaml.Node, source string, strict bool) ([]YamlValidationIssue, []YamlValidationIssue) {
+	var warnings []YamlValidationIssue
+
+	required := getRequiredNames(mta)
+	for i, resource := range mta.Resources {
+		if required[resource.Name] {
+			continue
+		}
+		msg := unusedResourceMsg
+		if resource.Active != nil && !*resource.Active {
+			msg = unusedInactiveResourceMsg
+		} else if resource.Optional {
+			msg = unusedOptionalResourceMsg
+		}
+		warnings = appendIssue(warnings, fmt.Sprintf(msg, resource.Name), getNamedObjectLineByIndex(mtaNode, resourcesYamlField, i))
+	}
+	return nil, warnings
+}
+
+// checkUnusedProvides warns on provided property sets which are not public
+// and are not listed in the requires of any module, hook or resource
+func checkUnusedProvides(mta *mta.MTA, mtaNode *yaml.Node, source string, strict bool) ([]YamlValidationIssue, []YamlValidationIssue) {
+	var warnings []YamlValidationIssue
+
+	required := getRequiredNames(mta)
+	modulesNode := getPropContent(mtaNode, modulesYamlField)
+	for i, module := range mta.Modules {
+		for j, provides := range module.Provides {
+			if provides.Public || required[provides.Name] {
+				continue
+			}
+			line := getNamedObjectLineByIndex(modulesNode[i], providesYamlField, j)
+			warnings = appendIssue(warnings, fmt.Sprintf(unusedProvidesMsg, provides.Name, module.Name), line)
+		}
+	}
+	return nil, warnings
+}
+
+// getRequiredNames returns the names in the requires of the modules, hooks and resources
+func getRequiredNames(mta *mta.MTA) map[string]bool {
+	required := make(map[string]bool)
+	for _, module := range mta.Modules {
+		for _, requires := range module.Requires {
+			required[requires.Name] = true
+		}
+		for _, hook := range module.Hooks {
+			for _, requires := range hook.Requires {
+				required[requires.Name] = true
+			}
+		}
+	}
+	for _, resource := range mta.Resources {
+		for _, requires := range resource.Requires {
+			required[requires.Name] = true
+		}
+	}
+	return required
+}
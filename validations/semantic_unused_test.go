@@ -0,0 +1,94 @@
+package validate
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("SemanticUnused", func() {
+	mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+ - name: ui5app
+   type: html5
+   requires:
+   - name: srv_api
+   provides:
+   - name: ui_api
+   - name: ui_public_api
+     public: true
+   hooks:
+   - name: hook1
+     requires:
+     - name: hook_db
+
+ - name: srv
+   type: java
+   provides:
+   - name: srv_api
+   requires:
+   - name: db
+
+resources:
+ - name: db
+   type: com.sap.xs.hdi-container
+
+ - name: hook_db
+   type: com.sap.xs.hdi-container
+
+ - name: config
+   type: configuration
+   requires:
+   - name: ui_config
+
+ - name: ui_config
+   type: org.cloudfoundry.managed-service
+
+ - name: unused
+   type: org.cloudfoundry.managed-service
+
+ - name: unused_optional
+   type: org.cloudfoundry.managed-service
+   optional: true
+
+ - name: unused_inactive
+   type: org.cloudfoundry.managed-service
+   optional: true
+   active: false
+`)
+
+	It("warns on unused resources", func() {
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		errors, warnings := checkUnusedResources(mta, node, "", true)
+		Ω(errors).Should(BeNil())
+		Ω(warnings).Should(Equal([]YamlValidationIssue{
+			{Msg: `the "config" resource is not required by any module, hook or resource`, Line: 34},
+			{Msg: `the "unused" resource is not required by any module, hook or resource`, Line: 42},
+			{Msg: `the "unused_optional" resource is optional and is not required by any module, hook or resource`, Line: 45},
+			{Msg: `the "unused_inactive" resource is not active and is not required by any module, hook or resource`, Line: 49},
+		}))
+	})
+
+	It("warns on unused provided property sets which are not public", func() {
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		errors, warnings := checkUnusedProvides(mta, node, "", true)
+		Ω(errors).Should(BeNil())
+		Ω(warnings).Should(Equal([]YamlValidationIssue{
+			{Msg: `the "ui_api" property set provided by the "ui5app" module is not required by any module, hook or resource and is not public`, Line: 12},
+		}))
+	})
+
+	It("is excluded by the exclude list", func() {
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		_, warnings := runSemanticValidations(mta, node, "", "paths,unusedResources,unusedProvides", true)
+		Ω(warnings).Should(BeEmpty())
+	})
+})
@@ -35,23 +35,32 @@ const (
 	publicYamlField             = "public"
 	listYamlField               = "list"
 	groupYamlField              = "group"
+	deployedAfterYamlField      = "deployed-after"
+
+	enableParallelDeploymentsYamlField = "enable-parallel-deployments"
 
 	npmOptsYamlField   = "npm-opts"
 	gruntOptsYamlField = "grunt-opts"
 	mavenOptsYamlField = "maven-opts"
 
-	pathsValidation          = "paths"
-	namesValidation          = "names"
-	requiredValidation       = "required"
-	buildersValidation       = "builders"
-	deprecatedOptsValidation = "deprecatedOpts"
-	deployerConstrValidation = "deployerConstraints"
-	metadataValidation       = "metadata"
-
-	propertiesMtaField      = "Properties"
-	parametersMtaField      = "Parameters"
-	buildParametersMtaField = "BuildParams"
-	nameMtaField            = "Name"
+	pathsValidation           = "paths"
+	namesValidation           = "names"
+	requiredValidation        = "required"
+	buildersValidation        = "builders"
+	deprecatedOptsValidation  = "deprecatedOpts"
+	deployerConstrValidation  = "deployerConstraints"
+	metadataValidation        = "metadata"
+	deployedAfterValidation   = "deployedAfter"
+	unusedResourcesValidation = "unusedResources"
+	unusedProvidesValidation  = "unusedProvides"
+	variablesValidation       = "variables"
+	placeholdersValidation    = "placeholders"
+	hooksValidation           = "hooks"
+	typesValidation           = "registeredTypes"
+	datatypesValidation       = "datatypes"
+	secretsValidation         = "plaintextSecrets"
+
+	nameMtaField = "Name"
 
 	moduleEntityKind       = "module"
 	resourceEntityKind     = "resource"
@@ -67,14 +76,35 @@ const (
 	html5ModuleType = "html5"
 )
 
+// semanticValidation - a semantic validation with the name of its rule
+type semanticValidation struct {
+	name  string
+	check checkSemantic
+}
+
 // runSemanticValidations - runs semantic validations
 func runSemanticValidations(mtaStr *mta.MTA, root *yaml.Node, source string, exclude string, strict bool) ([]YamlValidationIssue, []YamlValidationIssue) {
+	return runRuleSemanticValidations(mtaStr, root, source, exclude, newRuleContext(nil, root, "", strict))
+}
+
+// runRuleSemanticValidations - runs the semantic validations which are enabled by the rule configuration,
+// and applies the configuration and the suppression comments to their issues
+func runRuleSemanticValidations(mtaStr *mta.MTA, root *yaml.Node, source string, exclude string, rules *ruleContext) ([]YamlValidationIssue, []YamlValidationIssue) {
 	var errors []YamlValidationIssue
 	var warnings []YamlValidationIssue
 
-	validations := getSemanticValidations(exclude)
+	validations := getSemanticValidations(exclude, rules.types)
+	for _, rule := range rules.getCustomRules() {
+		if !isExcluded(exclude, rule.ID) {
+			validations = append(validations, semanticValidation{rule.ID, rule.checkMta})
+		}
+	}
 	for _, validation := range validations {
-		validationErrors, validationWarnings := validation(mtaStr, root, source, strict)
+		if !rules.isEnabled(validation.name) {
+			continue
+		}
+		validationErrors, validationWarnings := validation.check(mtaStr, root, source, rules.isStrict(validation.name))
+		validationErrors, validationWarnings = rules.apply(validation.name, validationErrors, validationWarnings)
 		errors = append(errors, validationErrors...)
 		warnings = append(warnings, validationWarnings...)
 
@@ -82,34 +112,51 @@ func runSemanticValidations(mtaStr *mta.MTA, root *yaml.Node, source string, exc
 	return errors, warnings
 }
 
-// getSemanticValidations - gets list of all semantic validations minus excludes validations
-func getSemanticValidations(exclude string) []checkSemantic {
-	var validations []checkSemantic
-	if !strings.Contains(exclude, pathsValidation) {
-		validations = append(validations, ifModulePathExists)
-	}
-	if !strings.Contains(exclude, namesValidation) {
-		validations = append(validations, isNameUnique)
+// getSemanticValidations - gets list of all semantic validations minus excludes validations;
+// the types validation checks the types of the registry
+func getSemanticValidations(exclude string, types *TypeRegistry) []semanticValidation {
+	allValidations := []semanticValidation{
+		{pathsValidation, ifModulePathExists},
+		{namesValidation, isNameUnique},
+		{requiredValidation, ifRequiredDefined},
+		{variablesValidation, checkVariables},
+		{placeholdersValidation, checkPlaceholders},
+		{hooksValidation, checkHooks},
+		{typesValidation, types.checkTypes},
+		{buildersValidation, checkBuildersSemantic},
+		{deprecatedOptsValidation, checkDeprecatedOpts},
+		{deployerConstrValidation, checkDeployerConstraints},
+		{metadataValidation, checkParamsAndPropertiesMetadata},
+		{datatypesValidation, checkDatatypes},
+		{secretsValidation, checkPlaintextSecrets},
+		{deployedAfterValidation, checkDeployedAfter},
+		{unusedResourcesValidation, checkUnusedResources},
+		{unusedProvidesValidation, checkUnusedProvides},
 	}
-	if !strings.Contains(exclude, requiredValidation) {
-		validations = append(validations, ifRequiredDefined)
+	var validations []semanticValidation
+	for _, validation := range allValidations {
+		if isExcluded(exclude, validation.name) {
+			continue
+		}
+		// the variables were checked by the required validation, so excluding it also excludes their validation
+		if validation.name == variablesValidation && isExcluded(exclude, requiredValidation) {
+			continue
+		}
+		validations = append(validations, validation)
 	}
-	if !strings.Contains(exclude, buildersValidation) {
-		validations = append(validations, checkBuildersSemantic)
-	}
-	if !strings.Contains(exclude, deprecatedOptsValidation) {
-		validations = append(validations, checkDeprecatedOpts)
-	}
-	if !strings.Contains(exclude, deployerConstrValidation) {
-		validations = append(validations, checkDeployerConstraints)
-	}
-	if !strings.Contains(exclude, metadataValidation) {
-		validations = append(validations, checkParamsAndPropertiesMetadata)
-	}
-
 	return validations
 }
 
+// isExcluded checks if the validation is in the comma-separated list of the excluded validations
+func isExcluded(exclude string, name string) bool {
+	for _, excluded := range strings.Split(exclude, ",") {
+		if strings.TrimSpace(excluded) == name {
+			return true
+		}
+	}
+	return false
+}
+
 func getIndexedNodePropLine(node *yaml.Node, index int, propName string) (line int, propFound bool) {
 	indexedNode := node.Content[index]
 	nameNode := getPropValueByName(indexedNode, propName)
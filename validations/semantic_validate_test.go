@@ -52,3 +52,11 @@ resources:
 	})
 
 })
+
+var _ = Describe("getSemanticValidations", func() {
+	It("excludes the validations by their whole names", func() {
+		all := len(getSemanticValidations("", nil))
+		Ω(len(getSemanticValidations(datatypesValidation, nil))).Should(Equal(all - 1))
+		Ω(len(getSemanticValidations("paths, names", nil))).Should(Equal(all - 2))
+	})
+})
@@ -0,0 +1,155 @@
+package validate
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"strings"
+
+	"github.com/SAP/cloud-mta/mta"
+	"github.com/SAP/cloud-mta/resolver"
+)
+
+const (
+	variableWithoutProviderMsg = `the "%s" %s of the %s is unresolved; the "%s" property is not provided`
+	variableUnknownPropertyMsg = `the "%s" %s of the %s is unresolved; the "%s/%s" property is not provided`
+	variableNotRequiredMsg     = `the "%s" %s of the %s is unresolved; the "%s" property set is not required`
+)
+
+// variablesChecker checks the ~{...} variables in the values of an MTA entity
+type variablesChecker struct {
+	provided              map[string]map[string]interface{}
+	configurationProvided map[string]bool
+	issues                []YamlValidationIssue
+}
+
+// checkVariables checks that the ~{...} variables in the properties and parameters of the modules, provided property sets,
+// hooks and resources can be resolved. The variables are parsed by the same rules as in the resolver.
+// A variable in a requires entry refers to a property of the required property set. A variable outside
+// a requires entry must be prefixed by the name of a property set required by the entity, like ~{srv_api/url}.
+// Properties of resources of configuration type are not known, so they are not checked.
+func checkVariables(mta *mta.MTA, mtaNode *yaml.Node, source string, strict bool) ([]YamlValidationIssue, []YamlValidationIssue) {
+	provided, configurationProvided := getProvidedPropertySets(mta)
+	c := variablesChecker{provided: provided, configurationProvided: configurationProvided}
+
+	modulesNode := getPropContent(mtaNode, modulesYamlField)
+	for i, module := range mta.Modules {
+		moduleDesc := fmt.Sprintf(`"%s" module`, module.Name)
+		required := getRequiresNames(module.Requires)
+		c.checkEntity(modulesNode[i], moduleDesc, required)
+
+		providesNode := getPropContent(modulesNode[i], providesYamlField)
+		for j, provides := range module.Provides {
+			c.checkEntity(providesNode[j], fmt.Sprintf(`"%s" provided property set of the %s`, provides.Name, moduleDesc), required)
+		}
+
+		hooksNode := getPropContent(modulesNode[i], hooksYamlField)
+		for j, hook := range module.Hooks {
+			// the hooks are executed in the context of the module, so they can refer to the property sets it requires
+			hookRequired := getRequiresNames(hook.Requires)
+			for name := range required {
+				hookRequired[name] = true
+			}
+			c.checkEntity(hooksNode[j], fmt.Sprintf(`"%s" hook of the %s`, hook.Name, moduleDesc), hookRequired)
+		}
+	}
+
+	resourcesNode := getPropContent(mtaNode, resourcesYamlField)
+	for i, resource := range mta.Resources {
+		c.checkEntity(resourcesNode[i], fmt.Sprintf(`"%s" resource`, resource.Name), getRequiresNames(resource.Requires))
+	}
+	return c.issues, nil
+}
+
+func getRequiresNames(requires []mta.Requires) map[string]bool {
+	names := make(map[string]bool)
+	for _, req := range requires {
+		names[req.Name] = true
+	}
+	return names
+}
+
+// checkEntity checks the variables in the properties, parameters and build parameters of the entity
+// and in the properties and parameters of its requires entries
+func (c *variablesChecker) checkEntity(node *yaml.Node, entityDesc string, required map[string]bool) {
+	c.checkFields(node, "", entityDesc, required)
+	for _, requiresNode := range getPropContent(node, requiresYamlField) {
+		nameNode := getPropValueByName(requiresNode, nameYamlField)
+		if nameNode != nil {
+			c.checkFields(requiresNode, nameNode.Value, entityDesc, required)
+		}
+	}
+}
+
+func (c *variablesChecker) checkFields(node *yaml.Node, requiresScope string, entityDesc string, required map[string]bool) {
+	fields := []struct {
+		name string
+		kind string
+	}{
+		{propertiesYamlField, propertyEntityKind},
+		{parametersYamlField, parameterEntityKind},
+		{buildParametersYamlField, buildParamEntityKind},
+	}
+	for _, field := range fields {
+		// the build parameters of requires entries are not resolved
+		if field.name == buildParametersYamlField && len(requiresScope) > 0 {
+			continue
+		}
+		valuesNode := getPropValueByName(node, field.name)
+		if valuesNode == nil || valuesNode.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(valuesNode.Content); i += 2 {
+			forEachStringValue(valuesNode.Content[i+1], valuesNode.Content[i].Value, func(name string, valueNode *yaml.Node) {
+				for _, variable := range resolver.GetVariables(valueNode.Value) {
+					c.issues = appendIssue(c.issues,
+						c.checkVariable(variable, name, field.kind, requiresScope, entityDesc, required), valueNode.Line)
+				}
+			})
+		}
+	}
+}
+
+// forEachStringValue calls the function for each scalar value nested in the node, with the name of the value;
+// the name of a value nested in a map is prefixed by the names of the enclosing keys
+func forEachStringValue(node *yaml.Node, name string, f func(name string, valueNode *yaml.Node)) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		f(name, node)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			forEachStringValue(node.Content[i+1], name+"."+node.Content[i].Value, f)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			forEachStringValue(item, name, f)
+		}
+	}
+}
+
+// checkVariable returns the issue of the variable, or an empty string if it can be resolved
+func (c *variablesChecker) checkVariable(variable, name, kind, requiresScope, entityDesc string, required map[string]bool) string {
+	providerName, propName := requiresScope, variable
+	if len(requiresScope) == 0 {
+		parts := strings.SplitN(variable, "/", 2)
+		if len(parts) != 2 {
+			return fmt.Sprintf(variableWithoutProviderMsg, name, kind, entityDesc, variable)
+		}
+		providerName, propName = parts[0], parts[1]
+		if !required[providerName] {
+			return fmt.Sprintf(variableNotRequiredMsg, name, kind, entityDesc, providerName)
+		}
+	}
+
+	if c.configurationProvided[providerName] {
+		return ""
+	}
+	props, ok := c.provided[providerName]
+	if !ok {
+		// the requires entry of an unknown property set is reported by the "required" validation
+		return ""
+	}
+	if _, ok = props[propName]; !ok {
+		return fmt.Sprintf(variableUnknownPropertyMsg, name, kind, entityDesc, providerName, propName)
+	}
+	return ""
+}
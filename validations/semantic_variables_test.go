@@ -0,0 +1,147 @@
+package validate
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("SemanticVariables", func() {
+	It("check required properties (placeholders usage)", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '2.1'
+version: 0.0.1
+
+modules:
+ - name: pricing-ui
+   type: javascript.nodejs
+   requires:
+   - name: price_opt 
+     properties:
+       conn_string: "~{protocol}://~{uri}/odata/" 
+       conn_string1: "~{protocol1}://~{uri}/odata/"
+       x: 
+         xa: "~{protocol}://~{uri}/odata/"
+   - name: unknown
+     properties:
+       conn_string2: "~{protocol}://~{uri}/odata/"
+
+   properties: 
+     conn_string3: "~{protocol}://~{uri}/odata/" 
+     a: "~{price_opt/protocol}://~{price_opt/uri}/odata/"
+     b: "~{price_opt/protocol1}://~{price_opt/uri}/odata/"
+     c: "~{price_opt1/protocol}://~{price_opt/uri}/odata/"
+     complex: 
+       a: "~{price_opt1/protocol}://~{price_opt/uri}/odata/"
+       b: "~{price_opt/address}://~{price_opt/address1}/odata/"
+   parameters: 
+     aaa: "~{protocol}://~{uri}/odata/" 
+
+ - name: pricing-backend
+   type: html5
+   provides:
+   - name: price_opt
+     properties:
+       protocol: http
+       uri: myhost.mydomain
+       uri1: ~{aaaa}
+       uri2: ~{pricing-ui/a}
+       address: 
+         protocolX: http
+         uriX: myhost.mydomain
+
+ - name1: unnamed
+   type: html5
+   properties: 
+     conn_string: "~{price_opt/protocol}://~{price_opt/uri}/odata/" 
+`)
+		mta, err := mta.Unmarshal(mtaContent)
+		Ω(err).Should(HaveOccurred())
+		node, _ := getContentNode(mtaContent)
+		issues, _ := checkVariables(mta, node, "", true)
+		Ω(issues).Should(Equal([]YamlValidationIssue{
+			{Msg: `the "conn_string3" property of the "pricing-ui" module is unresolved; the "protocol" property is not provided`, Line: 21},
+			{Msg: `the "conn_string3" property of the "pricing-ui" module is unresolved; the "uri" property is not provided`, Line: 21},
+			{Msg: `the "b" property of the "pricing-ui" module is unresolved; the "price_opt/protocol1" property is not provided`, Line: 23},
+			{Msg: `the "c" property of the "pricing-ui" module is unresolved; the "price_opt1" property set is not required`, Line: 24},
+			{Msg: `the "complex.a" property of the "pricing-ui" module is unresolved; the "price_opt1" property set is not required`, Line: 26},
+			{Msg: `the "complex.b" property of the "pricing-ui" module is unresolved; the "price_opt/address1" property is not provided`, Line: 27},
+			{Msg: `the "aaa" parameter of the "pricing-ui" module is unresolved; the "protocol" property is not provided`, Line: 29},
+			{Msg: `the "aaa" parameter of the "pricing-ui" module is unresolved; the "uri" property is not provided`, Line: 29},
+			{Msg: `the "conn_string1" property of the "pricing-ui" module is unresolved; the "price_opt/protocol1" property is not provided`, Line: 13},
+			{Msg: `the "uri1" property of the "price_opt" provided property set of the "pricing-backend" module is unresolved; the "aaaa" property is not provided`, Line: 38},
+			{Msg: `the "uri2" property of the "price_opt" provided property set of the "pricing-backend" module is unresolved; the "pricing-ui" property set is not required`, Line: 39},
+			{Msg: `the "conn_string" property of the "" module is unresolved; the "price_opt" property set is not required`, Line: 47},
+			{Msg: `the "conn_string" property of the "" module is unresolved; the "price_opt" property set is not required`, Line: 47},
+		}))
+	})
+})
+
+var _ = Describe("SemanticVariables in hooks, lists and resources", func() {
+	It("Sanity", func() {
+		mtaContent := []byte(`
+ID: mtahtml5
+_schema-version: '3.2'
+version: 0.0.1
+
+modules:
+ - name: srv
+   type: java
+   requires:
+   - name: db
+   provides:
+   - name: srv_api
+     properties:
+       url: ${default-url}
+   hooks:
+   - name: hook1
+     parameters:
+       commands:
+       - ~{db/user}
+       - ~{srv_api/url}
+     requires:
+     - name: srv_api
+       parameters:
+         urls: ["~{url}", "~{{url}}"]
+
+resources:
+ - name: db
+   type: com.sap.xs.hdi-container
+   properties:
+     user: admin
+     url: ~{srv_api/url}
+
+ - name: config
+   type: configuration
+   requires:
+   - name: srv_api
+     properties:
+       url: ~{url}/~{unknown}
+`)
+		mta, _ := mta.Unmarshal(mtaContent)
+		node, _ := getContentNode(mtaContent)
+		issues, _ := checkVariables(mta, node, "", true)
+		Ω(issues).Should(Equal([]YamlValidationIssue{
+			{Msg: `the "urls" parameter of the "hook1" hook of the "srv" module is unresolved; the "srv_api/{url}" property is not provided`, Line: 24},
+			{Msg: `the "url" property of the "db" resource is unresolved; the "srv_api" property set is not required`, Line: 31},
+			{Msg: `the "url" property of the "config" resource is unresolved; the "srv_api/unknown" property is not provided`, Line: 38},
+		}))
+	})
+})
+
+var _ = Describe("getSemanticValidations", func() {
+	It("excludes the variables validation with the required validation", func() {
+		getNames := func(validations []semanticValidation) []string {
+			var names []string
+			for _, validation := range validations {
+				names = append(names, validation.name)
+			}
+			return names
+		}
+		Ω(getNames(getSemanticValidations(requiredValidation, nil))).ShouldNot(ContainElement(variablesValidation))
+		Ω(getNames(getSemanticValidations(variablesValidation, nil))).Should(ContainElement(requiredValidation))
+		Ω(getNames(getSemanticValidations("", nil))).Should(ContainElement(variablesValidation))
+	})
+})
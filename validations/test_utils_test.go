@@ -13,5 +13,5 @@ func assertNoValidationErrors(errors []YamlValidationIssue) {
 }
 
 func expectSingleValidationError(actual []YamlValidationIssue, expectedMsg string, expectedLine int) {
-	Ω(actual).Should(ConsistOf(YamlValidationIssue{expectedMsg, expectedLine}))
+	Ω(actual).Should(ConsistOf(YamlValidationIssue{Msg: expectedMsg, Line: expectedLine}))
 }
@@ -0,0 +1,113 @@
+package validate
+
+import (
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	parseTypesMsg = `could not parse the types definition`
+	readTypesMsg  = `could not read the "%s" types definition file`
+)
+
+// TypeDefinition declares a module type or a resource type and the parameters of the modules or resources of the type
+type TypeDefinition struct {
+	Name string `yaml:"name"`
+	// RequiredParameters must be defined by every module or resource of the type
+	RequiredParameters []string `yaml:"required-parameters,omitempty"`
+	// Parameters are the other parameters known for the type; if there are no required or known parameters,
+	// any parameter is accepted
+	Parameters []string `yaml:"parameters,omitempty"`
+}
+
+// typeDefinitions is the format of the types definition files
+type typeDefinitions struct {
+	ModuleTypes   []TypeDefinition `yaml:"module-types,omitempty"`
+	ResourceTypes []TypeDefinition `yaml:"resource-types,omitempty"`
+}
+
+// TypeRegistry holds the known module types and resource types, which are checked by the semantic validations
+type TypeRegistry struct {
+	moduleTypes   map[string]TypeDefinition
+	resourceTypes map[string]TypeDefinition
+}
+
+// NewTypeRegistry returns a type registry with the built-in module types and resource types of the Cloud Foundry
+// and XS advanced deployers; more types can be added to it, for example from a types definition file.
+// The semantic validations of a project use a registry with the types of the "types" files of its rule configuration.
+func NewTypeRegistry() *TypeRegistry {
+	r := &TypeRegistry{
+		moduleTypes:   make(map[string]TypeDefinition),
+		resourceTypes: make(map[string]TypeDefinition),
+	}
+	// the built-in types definition is embedded in the code, so it can be parsed
+	_ = r.Load(builtinTypesDef)
+	return r
+}
+
+// AddModuleType adds the module type to the registry, or replaces the type with the same name
+func (r *TypeRegistry) AddModuleType(def TypeDefinition) {
+	r.moduleTypes[def.Name] = def
+}
+
+// AddResourceType adds the resource type to the registry, or replaces the type with the same name
+func (r *TypeRegistry) AddResourceType(def TypeDefinition) {
+	r.resourceTypes[def.Name] = def
+}
+
+// GetModuleType returns the definition of the module type, and false if the type is not known
+func (r *TypeRegistry) GetModuleType(name string) (TypeDefinition, bool) {
+	def, ok := r.moduleTypes[name]
+	return def, ok
+}
+
+// GetResourceType returns the definition of the resource type, and false if the type is not known
+func (r *TypeRegistry) GetResourceType(name string) (TypeDefinition, bool) {
+	def, ok := r.resourceTypes[name]
+	return def, ok
+}
+
+// Load adds the types in the YAML types definition to the registry. The definition has a "module-types" list
+// and a "resource-types" list; each type has a name, "required-parameters" and known "parameters".
+func (r *TypeRegistry) Load(content []byte) error {
+	var defs typeDefinitions
+	if err := yaml.Unmarshal(content, &defs); err != nil {
+		return errors.Wrap(err, parseTypesMsg)
+	}
+	for _, def := range defs.ModuleTypes {
+		r.AddModuleType(def)
+	}
+	for _, def := range defs.ResourceTypes {
+		r.AddResourceType(def)
+	}
+	return nil
+}
+
+// LoadFile adds the types in the YAML types definition file to the registry
+func (r *TypeRegistry) LoadFile(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, readTypesMsg, path)
+	}
+	return errors.Wrapf(r.Load(content), readTypesMsg, path)
+}
+
+// isParameterKnown returns true if the parameter is required or known for the type,
+// or if the type accepts any parameter
+func (def TypeDefinition) isParameterKnown(name string) bool {
+	if len(def.RequiredParameters) == 0 && len(def.Parameters) == 0 {
+		return true
+	}
+	return containsString(def.RequiredParameters, name) || containsString(def.Parameters, name)
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package validate
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TypeRegistry", func() {
+	It("holds the built-in types", func() {
+		r := NewTypeRegistry()
+		def, ok := r.GetModuleType("nodejs")
+		Ω(ok).Should(BeTrue())
+		Ω(def.Parameters).Should(ContainElement("memory"))
+		def, ok = r.GetResourceType("org.cloudfoundry.managed-service")
+		Ω(ok).Should(BeTrue())
+		Ω(def.RequiredParameters).Should(Equal([]string{"service", "service-plan"}))
+		_, ok = r.GetModuleType("nodjs")
+		Ω(ok).Should(BeFalse())
+	})
+
+	It("adds types", func() {
+		r := NewTypeRegistry()
+		r.AddModuleType(TypeDefinition{Name: "nodjs"})
+		r.AddResourceType(TypeDefinition{Name: "com.acme.queue", RequiredParameters: []string{"size"}})
+		_, ok := r.GetModuleType("nodjs")
+		Ω(ok).Should(BeTrue())
+		def, ok := r.GetResourceType("com.acme.queue")
+		Ω(ok).Should(BeTrue())
+		Ω(def.RequiredParameters).Should(Equal([]string{"size"}))
+	})
+
+	It("loads types from a file", func() {
+		r := NewTypeRegistry()
+		Ω(r.LoadFile(getTestPath("types", "types.yaml"))).Should(Succeed())
+		def, ok := r.GetModuleType("com.acme.worker")
+		Ω(ok).Should(BeTrue())
+		Ω(def).Should(Equal(TypeDefinition{Name: "com.acme.worker", RequiredParameters: []string{"queue"}, Parameters: []string{"memory"}}))
+		// the built-in type is replaced
+		def, _ = r.GetResourceType("org.cloudfoundry.managed-service")
+		Ω(def.RequiredParameters).Should(Equal([]string{"service"}))
+		// the other registries are not changed
+		def, _ = NewTypeRegistry().GetResourceType("org.cloudfoundry.managed-service")
+		Ω(def.RequiredParameters).Should(Equal([]string{"service", "service-plan"}))
+	})
+
+	It("fails when the file does not exist", func() {
+		Ω(NewTypeRegistry().LoadFile(getTestPath("types", "unknown.yaml"))).Should(HaveOccurred())
+	})
+
+	It("fails when the file cannot be parsed", func() {
+		Ω(NewTypeRegistry().LoadFile(getTestPath("types", "invalid.yaml"))).Should(HaveOccurred())
+	})
+})